@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/vtrifonov/http-api-mock/journal"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/route"
+	"github.com/vtrifonov/http-api-mock/scenario"
+)
+
+//Dispatcher is the http admin server. It allows managing the mock server at runtime.
+type Dispatcher struct {
+	IP        string
+	Port      int
+	Mocks     route.MockManager
+	Journal   *journal.Journal
+	Scenarios *scenario.Store
+}
+
+//Handler returns the http handler serving the admin API.
+func (di *Dispatcher) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/__admin/mocks", di.mocksHandler)
+	mux.HandleFunc("/__admin/mocks/", di.mockHandler)
+	mux.HandleFunc("/__admin/scenarios", di.scenariosHandler)
+	mux.HandleFunc("/__admin/scenarios/", di.scenarioHandler)
+	mux.HandleFunc("/__admin/sequences", di.sequencesHandler)
+	mux.HandleFunc("/__admin/sequences/", di.sequenceHandler)
+	if di.Journal != nil {
+		mux.HandleFunc("/__admin/requests", di.requestsHandler)
+		mux.HandleFunc("/__admin/requests/verify", di.verifyHandler)
+	}
+	return mux
+}
+
+//Start initiates the http admin server.
+func (di *Dispatcher) Start() {
+	addr := fmt.Sprintf("%s:%d", di.IP, di.Port)
+	err := http.ListenAndServe(addr, di.Handler())
+	if err != nil {
+		logging.Fatalf("ListenAndServe: %s", err.Error())
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "    ")
+	encoder.Encode(value)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	writeJSON(w, statusCode, map[string]string{"error": err.Error()})
+}
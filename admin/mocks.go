@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/route"
+)
+
+var (
+	//ErrInvalidMockDefinition when the request body is not a valid mock definition
+	ErrInvalidMockDefinition = errors.New("Invalid mock definition")
+	//ErrMissingMockName when the mock name is not part of the url
+	ErrMissingMockName = errors.New("Mock name is missing")
+	//ErrMethodNotAllowed when the admin resource doesn't support the http method
+	ErrMethodNotAllowed = errors.New("Method not allowed")
+)
+
+const mocksPath = "/__admin/mocks/"
+
+//mocksHandler lists all the mock definitions.
+func (di *Dispatcher) mocksHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, di.Mocks.GetMockDefinitions())
+}
+
+//mockHandler gets, adds, replaces and deletes a single mock definition identified by its name.
+func (di *Dispatcher) mockHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, mocksPath)
+	if name == "" {
+		if r.Method == http.MethodGet {
+			di.mocksHandler(w, r)
+			return
+		}
+		writeError(w, http.StatusBadRequest, ErrMissingMockName)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		mock, found := di.Mocks.GetMockDefinition(name)
+		if !found {
+			writeError(w, http.StatusNotFound, route.ErrMockNotFound)
+			return
+		}
+		writeJSON(w, http.StatusOK, mock)
+	case http.MethodPost:
+		mock, err := readMock(r, name)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		if err := di.Mocks.AddMockDefinition(mock); err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, mock)
+	case http.MethodPut:
+		mock, err := readMock(r, name)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		statusCode := http.StatusOK
+		if di.Mocks.UpdateMockDefinition(mock) {
+			statusCode = http.StatusCreated
+		}
+		writeJSON(w, statusCode, mock)
+	case http.MethodDelete:
+		err := di.Mocks.DeleteMockDefinition(name)
+		if err == route.ErrMockNotFound {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusConflict, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+	}
+}
+
+//readMock reads the mock definition from the request body and names it after the url.
+func readMock(r *http.Request, name string) (definition.Mock, error) {
+	mock := definition.Mock{}
+	if err := json.NewDecoder(r.Body).Decode(&mock); err != nil {
+		return mock, ErrInvalidMockDefinition
+	}
+	if mock.Request.Method == "" || mock.Request.Path == "" {
+		return mock, ErrInvalidMockDefinition
+	}
+	mock.Name = name
+	return mock, nil
+}
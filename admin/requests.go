@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/journal"
+)
+
+//ErrInvalidVerification when the request body is not a valid verification
+var ErrInvalidVerification = errors.New("Invalid verification")
+
+//requestsHandler lists the journal entries matching the query string filter or resets the journal.
+func (di *Dispatcher) requestsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, di.Journal.Find(getFilterFromQuery(r)))
+	case http.MethodDelete:
+		di.Journal.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+	}
+}
+
+//verifyHandler checks the number of journal entries matching the verification in the request body.
+//It returns 417 Expectation Failed if the verification doesn't pass.
+func (di *Dispatcher) verifyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+
+	verification := journal.Verification{}
+	if err := json.NewDecoder(r.Body).Decode(&verification); err != nil {
+		writeError(w, http.StatusBadRequest, ErrInvalidVerification)
+		return
+	}
+
+	result := di.Journal.Verify(verification)
+	statusCode := http.StatusOK
+	if !result.Verified {
+		statusCode = http.StatusExpectationFailed
+	}
+	writeJSON(w, statusCode, result)
+}
+
+//getFilterFromQuery builds the journal filter from the query string, headers are passed as header=Name:glob.
+func getFilterFromQuery(r *http.Request) journal.Filter {
+	query := r.URL.Query()
+	filter := journal.Filter{
+		Mock:    query.Get("mock"),
+		Method:  query.Get("method"),
+		Path:    query.Get("path"),
+		Body:    query.Get("body"),
+		Headers: make(map[string]string),
+	}
+	for _, header := range query["header"] {
+		if i := strings.Index(header, ":"); i > 0 {
+			filter.Headers[strings.TrimSpace(header[:i])] = strings.TrimSpace(header[i+1:])
+		}
+	}
+	return filter
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+//ErrInvalidScenarioState when the request body doesn't contain a valid scenario state
+var ErrInvalidScenarioState = errors.New("Invalid scenario state")
+
+const scenariosPath = "/__admin/scenarios/"
+
+//scenarioState is the body used for changing the state of a scenario
+type scenarioState struct {
+	State string `json:"state"`
+}
+
+//scenariosHandler lists the state of all the scenarios or resets them.
+func (di *Dispatcher) scenariosHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, di.getScenarioStates())
+	case http.MethodDelete:
+		di.Scenarios.Reset()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+	}
+}
+
+//scenarioHandler gets, changes or resets the state of a single scenario.
+func (di *Dispatcher) scenarioHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, scenariosPath)
+	if name == "" {
+		di.scenariosHandler(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, scenarioState{State: di.Scenarios.GetState(name)})
+	case http.MethodPut:
+		state := scenarioState{}
+		if err := json.NewDecoder(r.Body).Decode(&state); err != nil || state.State == "" {
+			writeError(w, http.StatusBadRequest, ErrInvalidScenarioState)
+			return
+		}
+		di.Scenarios.SetState(name, state.State)
+		writeJSON(w, http.StatusOK, state)
+	case http.MethodDelete:
+		di.Scenarios.ResetScenario(name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+	}
+}
+
+//getScenarioStates returns the state of the scenarios used by the mocks and the ones which have changed.
+func (di *Dispatcher) getScenarioStates() map[string]string {
+	states := di.Scenarios.States()
+	for _, mock := range di.Mocks.GetMockDefinitions() {
+		if name := mock.Control.Scenario; name != "" {
+			if _, ok := states[name]; !ok {
+				states[name] = di.Scenarios.GetState(name)
+			}
+		}
+	}
+	return states
+}
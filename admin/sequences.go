@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+)
+
+const sequencesPath = "/__admin/sequences/"
+
+//sequencesHandler starts over the response sequences of all the mocks.
+func (di *Dispatcher) sequencesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+	di.Mocks.ResetSequences()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//sequenceHandler starts over the response sequence of a single mock.
+func (di *Dispatcher) sequenceHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, sequencesPath)
+	if name == "" {
+		di.sequencesHandler(w, r)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		writeError(w, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+	di.Mocks.ResetSequence(name)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,153 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"time"
+)
+
+var (
+	//ErrMissingKeyFile when only the certificate file is provided
+	ErrMissingKeyFile = errors.New("Both certificate and key files should be provided")
+	//ErrInvalidCAFile when the client certificate authorities file doesn't contain PEM certificates
+	ErrInvalidCAFile = errors.New("No PEM certificate found in the client certificate authorities file")
+)
+
+//certificateValidity is the validity of the generated certificates
+const certificateValidity = 365 * 24 * time.Hour
+
+//Authority is a self-signed certificate authority used for issuing the mock server certificates.
+type Authority struct {
+	Certificate *x509.Certificate
+	PrivateKey  *ecdsa.PrivateKey
+}
+
+//NewAuthority generates a new self-signed certificate authority.
+func NewAuthority(commonName string) (*Authority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Authority{Certificate: cert, PrivateKey: key}, nil
+}
+
+//Issue generates a server certificate signed by the authority and valid for the given host names and IPs.
+func (a *Authority) Issue(hosts []string) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	commonName := "localhost"
+	if len(hosts) > 0 {
+		commonName = hosts[0]
+	}
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if host != "" {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, a.Certificate, &key.PublicKey, a.PrivateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der, a.Certificate.Raw}, PrivateKey: key}, nil
+}
+
+//CertificatePEM returns the authority certificate PEM encoded.
+func (a *Authority) CertificatePEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: a.Certificate.Raw})
+}
+
+//WriteCertificate exports the authority certificate to a PEM file, so the clients can trust it.
+func (a *Authority) WriteCertificate(path string) error {
+	return ioutil.WriteFile(path, a.CertificatePEM(), 0644)
+}
+
+//LoadCertificate reads a certificate and its private key from PEM files.
+func LoadCertificate(certFile, keyFile string) (tls.Certificate, error) {
+	if keyFile == "" {
+		return tls.Certificate{}, ErrMissingKeyFile
+	}
+	return tls.LoadX509KeyPair(certFile, keyFile)
+}
+
+//NewTLSConfig returns a TLS configuration serving the given certificate.
+func NewTLSConfig(cert tls.Certificate) *tls.Config {
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+}
+
+//ConfigureClientAuth returns a copy of the configuration asking the clients for certificates, so they can be used for matching the requests.
+//The given configuration is not changed, as it is shared with the console. The certificates are verified only if there is a file with the trusted authorities.
+func ConfigureClientAuth(config *tls.Config, caFile string, required bool) (*tls.Config, error) {
+	config = config.Clone()
+	if caFile == "" {
+		config.ClientAuth = tls.RequestClientCert
+		if required {
+			config.ClientAuth = tls.RequireAnyClientCert
+		}
+		return config, nil
+	}
+
+	data, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, ErrInvalidCAFile
+	}
+	config.ClientCAs = pool
+	config.ClientAuth = tls.VerifyClientCertIfGiven
+	if required {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+func newTemplate(commonName string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"HTTP API Mock"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certificateValidity),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+)
+
+func TestAuthority_Issue(t *testing.T) {
+	ca, err := NewAuthority("HTTP API Mock CA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := ca.Issue([]string{"localhost", "127.0.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca.CertificatePEM()) {
+		t.Fatal("The authority certificate should be PEM encoded")
+	}
+	for _, host := range []string{"localhost", "127.0.0.1"} {
+		if _, err := leaf.Verify(x509.VerifyOptions{Roots: pool, DNSName: host}); err != nil {
+			t.Error("The certificate should be valid for", host, err)
+		}
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: pool, DNSName: "example.com"}); err == nil {
+		t.Error("The certificate should not be valid for other hosts")
+	}
+}
+
+func TestLoadCertificate_MissingKey(t *testing.T) {
+	if _, err := LoadCertificate("cert.pem", ""); err != ErrMissingKeyFile {
+		t.Error("The missing key file should be reported", err)
+	}
+}
+
+func TestConfigureClientAuth(t *testing.T) {
+	shared := &tls.Config{}
+	config, err := ConfigureClientAuth(shared, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ClientAuth != tls.RequestClientCert {
+		t.Error("The client certificate should be requested", config.ClientAuth)
+	}
+	if config, err = ConfigureClientAuth(shared, "", true); err != nil || config.ClientAuth != tls.RequireAnyClientCert {
+		t.Error("The client certificate should be required", config.ClientAuth, err)
+	}
+	if shared.ClientAuth != tls.NoClientCert {
+		t.Error("The shared configuration should not ask for client certificates", shared.ClientAuth)
+	}
+}
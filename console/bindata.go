@@ -90,7 +90,7 @@ func tmplCssStyleCss() (*asset, error) {
 	return a, nil
 }
 
-var _tmplIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xd4\x58\x6d\x53\xe3\x38\x12\xfe\x9c\xfc\x8a\x5e\xdd\x5d\x15\x53\x73\x8e\x09\x59\x66\x58\xb0\x53\xc5\x40\x76\xe7\xa8\x30\x64\x26\x1c\xdc\xee\x37\xd9\x6e\xdb\x4a\x64\xc9\x48\x72\x5e\xe6\x6a\xff\xfb\x95\xfc\x92\x18\x08\x0c\x6c\xcd\x55\xed\xf2\x21\x96\xad\xee\xe7\xe9\x57\xb5\x0a\xef\x87\xf3\xab\xb3\xeb\x5f\x27\x23\xf8\x78\x7d\x39\x1e\x76\xbd\xd4\x64\xdc\x3e\x90\x46\xc3\x6e\xc7\xcb\xd0\x50\x48\x8d\xc9\x1d\xbc\x2b\xd8\xc2\x27\x67\x52\x18\x14\xc6\xb9\x5e\xe7\x48\x20\xac\xde\x7c\x62\x70\x65\x5c\xab\x7b\x02\x61\x4a\x95\x46\xe3\xff\xfb\xfa\x67\xe7\x88\x80\x6b\x61\x0c\x33\x1c\x87\x1f\xaf\xaf\x27\x70\x3a\xf9\x17\x5c\xca\x70\x0e\x67\x52\x68\xc9\xd1\x73\xab\xcd\x6e\xb7\xe3\xe9\x50\xb1\xdc\x80\x56\xa1\x4f\x5c\x37\x94\x11\xf6\x66\x77\x05\xaa\x75\x2f\x94\x99\x5b\x2d\x9d\x7e\xaf\x7f\xd0\xdb\xef\x65\x4c\xf4\x66\x9a\x0c\x3d\xb7\xd2\x1a\xbe\x54\x3f\x63\x89\xa2\x06\x9d\x7e\xef\xa0\xd7\xdf\x01\xd3\xed\x78\x3f\x38\x0e\x8c\xa9\x41\x6d\x20\x94\x59\xce\x38\x46\x40\x45\x04\x19\x13\x2c\x66\x18\xc1\xd9\x74\x0a\x8e\x63\x29\x39\x13\x73\x50\xc8\x7d\xa2\xcd\x9a\xa3\x4e\x11\x0d\x81\x54\x61\xec\x13\x1b\x37\x7d\xec\xba\x19\x5d\x85\x91\xe8\x05\x52\x1a\x6d\x14\xcd\xed\x8b\x35\x68\xf3\xc1\x1d\xf4\x06\xbd\x77\x6e\xa8\xf5\xf6\x5b\x69\x59\xa8\x35\x01\x26\x0c\x26\x8a\x99\xb5\x4f\x74\x4a\x07\x47\x3f\x3a\xfd\xbb\xa3\xec\xfa\xe2\xea\x74\xba\x3a\x9a\xf5\x4f\x8b\xb7\xf4\xf0\xf6\xfc\x46\x4c\xd8\x01\x9f\xff\x1c\x2f\x97\xa3\x53\x7a\x94\x9e\x9f\x47\xb3\xdf\x78\x3e\xc6\x64\x95\xce\x6e\x2e\x47\xfd\x38\x99\xdd\x4e\x7e\xc9\xe6\x5f\xf5\x7b\x02\xa1\x92\x5a\x4b\xc5\x12\x26\x7c\x42\x85\x14\xeb\x4c\x16\x9a\x6c\xbc\xbf\xca\x0d\x93\x82\x72\x30\x29\x66\xf8\xff\xf6\xd5\x29\x59\x9e\xf3\x38\x1e\xdf\x1e\x7c\xda\xef\xf3\xcb\xbb\x19\x9d\x7f\x98\xaf\x06\xdc\xbd\xfc\x69\x44\xd3\x62\x99\x4f\x63\xfc\xb4\xb8\x79\x37\xb8\x38\xc4\xaf\x62\x50\xfc\xf6\x95\xe6\xd7\xfb\xc5\xfb\xd1\xaf\xfa\x3f\x97\xb3\xcf\x37\x6f\xf7\x47\xe2\x50\x7d\xdb\xe3\x67\xf3\x7d\x41\x17\x74\x5a\x15\x57\x15\x8a\x76\xa5\xbd\xd6\xf5\xd9\xc3\x2c\xcf\x76\xba\xbc\x9f\x4d\x83\x8b\xf3\xd1\x47\x46\x79\x9c\x15\x1f\x3e\x7c\x9e\xbc\x3b\xfd\xf1\xb3\xca\xd5\xdd\xe1\xd5\x4d\x7c\x3b\x78\x3f\xf9\xf2\x65\x30\x3b\x1c\x8d\xef\x56\x5a\xf7\xd7\x37\x77\x57\x46\x60\x2e\x3e\xde\x4c\x7e\xa2\x17\xef\x57\xd3\xa7\x5d\xbe\x57\xeb\xb5\x27\x66\x9d\x63\xdd\xc6\x5b\x67\x49\xe5\xe0\x4c\xd7\x0a\x8f\x3b\x65\x77\x49\xb4\xc0\xca\x7c\x56\x25\x62\x53\x5e\x4a\x95\x49\xde\x9c\x2e\x82\x66\xe8\x93\x05\xc3\x65\x2e\x95\x69\x9d\x29\x4b\x16\x99\xd4\x8f\x70\xc1\x42\x74\xca\x97\x7f\x02\x13\xcc\x30\xca\x1d\x1d\x52\x8e\x7e\xdf\xa2\x7c\xc3\x85\x61\xb7\xf3\xf7\x3d\x88\x64\x58\x64\x28\x0c\xbc\xe9\x29\xa4\xd1\x7a\x2f\x2e\x44\x68\x4b\x7c\xef\x0d\xfc\xb7\xdb\xe9\x00\x2c\xa8\x82\xa5\x06\x1f\x04\x2e\xe1\x16\x83\xa9\x0c\xe7\x68\xf6\xc8\xd2\xa6\x96\xc0\x5b\xe0\x32\xa4\x56\xa3\x97\x4a\x6d\xe0\x2d\x10\x17\xc3\x54\x92\x37\x27\xa5\xfa\x52\xf7\xa4\xc8\x50\x6b\x9a\x20\xf8\xb0\x81\xc7\x06\xbf\xa6\xd8\x8a\x5c\x4c\xaf\x3e\xf5\x72\x7b\x56\xee\xe1\x02\x85\xe9\x45\xd4\xd0\x1a\xad\x03\xc0\x65\xf2\x05\xef\x0a\xd4\x66\xaf\xd6\x79\x73\x62\xb7\xec\x5f\x29\xf3\xfb\x49\xb7\xdb\xe9\x74\xfe\x80\xdd\x5c\x26\x2f\x34\xbb\xc6\x7f\xb1\xd1\x4b\xc5\x0c\x8e\x65\xf2\xb4\xc9\x9d\xdf\x4b\xe9\x6d\x11\x79\x6e\x35\x6b\xba\x5d\x2f\x90\xd1\x7a\x58\xb7\xe3\xd4\xd0\x70\x6e\x4f\x1f\x08\x25\x2f\x32\xa1\x41\x0a\xc8\x64\xc0\x38\x42\xb0\x86\x8c\xce\x99\x48\x40\x0a\x84\xb8\xe0\xbc\x2a\x8f\xb2\x67\xad\x8a\x34\x29\x2a\x48\x29\x8f\xeb\x8d\xaa\x69\x23\xb6\x80\x90\x53\xad\x7d\x62\x8b\x8c\x32\x81\xca\x89\x79\xc1\xa2\xa6\xe8\x08\x94\x05\xea\x93\x9c\x46\x11\x13\xc9\x31\xf4\x0f\xf3\xd5\x49\x55\x66\x2d\x75\x25\x97\xe5\xb7\x07\x98\xdc\xc9\x22\xa7\x7f\x60\x4d\x76\x78\x52\xad\xca\x21\x57\x09\xdf\x93\xce\xa9\x40\x0e\xe5\xaf\x93\x2b\x96\x51\xb5\x26\x50\x89\x3d\x96\x73\x6c\x8c\x98\x48\x6a\x9c\x4e\xc7\x4b\x07\xf7\x05\xca\x21\x4a\x9e\x1a\xb1\xe9\xa0\x41\x76\x23\xb6\x78\x92\xc5\x26\x80\x00\x8b\x7c\x12\xb1\xc5\x2f\x4a\x16\x79\x8d\xb0\x89\x8b\x5c\xa0\x8a\xb9\x5c\x1e\xd3\xc2\xc8\x8d\x35\xf5\xa3\xe3\x15\xbc\x01\xe4\x4c\x1b\x27\xb1\x10\x15\x60\xd2\x46\x6b\xf4\x5a\x9a\x6e\xc1\xef\xa3\x3d\x6f\x69\x2c\xa5\x41\xb5\x0d\x07\xa7\x01\xf2\x07\xc9\x73\x8c\xcc\x8f\xa1\xff\x8f\x93\x2d\x9f\xc7\x44\x5e\x34\x27\x45\x98\x62\x38\x0f\xe4\xaa\x32\x30\x4c\xe7\xa7\x85\x91\xd3\x50\x49\xce\x09\x94\x9b\x18\xf9\xc4\xa8\x02\x09\x0c\xc1\x6e\x82\x2e\x77\x1b\x52\xb7\x64\xdd\xd8\x10\x14\xc6\x48\x51\x83\x57\x2f\xa4\xb1\x3a\x30\x02\x02\x23\xb6\x99\x36\x34\x60\x22\xc2\x95\x4f\xf6\x2b\xfe\xc0\x88\x33\x8e\x54\x3d\x8c\x78\xcc\x25\x35\xc7\x8a\x25\xa9\xd9\x3a\xe2\xe9\x9c\x8a\x06\x3b\xe1\xeb\x3c\x65\xa1\x14\xb0\x59\x39\x0a\x33\xb9\x40\x47\xb3\x44\x94\x27\x76\x4e\xc5\x10\x4a\xfc\x6d\x51\x54\x26\x3e\x2e\x8c\xed\xb2\x5a\x75\xba\xad\x6f\xdf\xec\x02\xca\x51\x19\x28\x7f\x1d\x26\x62\xb9\x71\x24\x62\x3a\xe7\x74\x7d\x2c\xa4\xc0\xca\xe5\x52\xe8\x12\xb5\x46\x91\x20\x19\xde\x22\x0f\x65\x86\xbd\x2d\xd7\x53\xa4\xf0\xa2\xde\xd3\x86\x9a\x72\xc2\xbf\xa4\xf5\xbe\x4f\xe7\x9d\xa3\xa1\x8c\xeb\xd7\x35\xdc\xb0\xdb\x40\xdb\x7d\x1b\x18\xc3\x14\x86\x34\x7f\x22\x74\x3b\x02\xad\x8b\x30\x44\x3b\x69\x1b\x2b\x01\x3c\x6d\x94\x14\xc9\xb0\x2a\x95\x0a\xb5\x89\x08\xd4\x15\xe1\xb9\xb5\x10\xb4\xa5\x54\x35\x76\xb6\x62\x9b\x7a\xdf\xba\xd2\x34\x6e\xab\xe3\x05\x5d\x80\xa0\x0b\xc7\xd0\x40\x93\x96\x1d\x9c\x6d\x2c\x0e\x0d\x5b\xd8\x66\xf2\x68\x7d\x25\xf8\x9b\xa1\x41\x43\x07\x76\x94\x38\x46\x26\x89\x75\xd9\xd0\x80\x0c\xeb\x01\xe8\xb9\x74\xe8\xb9\x9c\xdd\x03\x7d\x08\xa2\x73\x29\x34\xee\x46\xa9\xf6\x1e\xc2\x74\x1e\xa1\xe4\xa8\x34\xdb\x6d\xca\xa4\xda\x7a\x81\x29\x76\xba\xee\x00\x18\xcb\xe4\xa1\x72\x75\xe8\xdd\xcb\x7e\x1d\x29\x43\x03\xa7\x19\x49\x2d\xaa\x4d\x7d\xb4\xa2\xd6\xd2\xb0\x35\x05\x31\x8d\x10\x98\x80\x3a\xd8\x5b\x6d\x00\x2f\x57\x58\xea\xa7\xd1\x26\xc7\x9e\x9b\x2b\x6c\x51\xb4\x73\xfc\x90\xb1\x09\xf1\x2e\xca\xa7\x89\x6a\xad\x67\x99\x3a\x6d\xa2\x4d\x16\x5e\xc3\xd3\x28\xed\xa4\x81\xdd\x1e\x95\x99\x7a\x0d\x89\x55\x78\x9e\x60\x47\x8f\xd4\x9f\x76\x1e\xad\xdd\xee\xbd\xd5\x5f\xe1\x86\x31\x5a\x61\x58\xd8\x3b\x22\x8c\x65\xf2\xca\x93\xee\xde\xd5\x62\x6c\xa3\xff\x5d\xae\x15\x16\xe9\x4f\x7a\xa5\x18\xcb\xe4\x4f\x71\xab\x68\x07\xfb\xbb\xdf\x28\xca\xa3\xed\x8f\xdc\x26\x9a\xd2\xef\x76\x3d\xb7\xba\xfc\x7b\x6e\xf5\x0f\xa8\xff\x05\x00\x00\xff\xff\xa4\x7b\x35\x18\x98\x12\x00\x00")
+var _tmplIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xd4\x58\x6d\x53\xe3\x38\x12\xfe\xec\xfc\x8a\x5e\xdd\x5d\x15\x53\x73\xb6\xc9\x64\x99\x99\x05\x3b\x57\x0c\x64\x97\xa3\xc2\x90\x21\x1c\xdc\xee\x37\xc5\xee\xd8\x4a\x64\xc9\x48\x72\x5e\xe6\x6a\xff\xfb\x95\x6c\xc7\x31\x10\x18\xb8\xdd\xab\xda\xe5\x43\x22\x5b\xdd\xcf\xd3\x6f\x6a\x75\x08\xbe\x3b\xbd\x3c\xb9\xfe\x79\x34\x80\xb3\xeb\x8b\x61\xbf\x13\xa4\x26\xe3\xf6\x0b\x69\xdc\xef\x38\x41\x86\x86\x42\x6a\x4c\xee\xe2\x5d\xc1\x16\x21\x39\x91\xc2\xa0\x30\xee\xf5\x3a\x47\x02\x51\xf5\x14\x12\x83\x2b\xe3\x5b\xdd\x23\x88\x52\xaa\x34\x9a\xf0\x5f\xd7\x3f\xba\x1f\x09\xf8\x16\xc6\x30\xc3\xb1\x7f\x76\x7d\x3d\x82\xe3\xd1\x3f\xe1\x42\x46\x73\x38\x91\x42\x4b\x8e\x81\x5f\x6d\x76\x3a\x4e\xa0\x23\xc5\x72\x03\x5a\x45\x21\xf1\xfd\x48\xc6\xe8\xcd\xee\x0a\x54\x6b\x2f\x92\x99\x5f\x2d\xdd\xae\xd7\x7d\xe7\xed\x7b\x19\x13\xde\x4c\x93\x7e\xe0\x57\x5a\xfd\x97\xea\x67\x2c\x51\xd4\xa0\xdb\xf5\xde\x79\xdd\x1d\x30\x1d\x27\xf8\xce\x75\x61\x48\x0d\x6a\x03\x91\xcc\x72\xc6\x31\x06\x2a\x62\xc8\x98\x60\x53\x86\x31\x9c\x8c\xc7\xe0\xba\x96\x92\x33\x31\x07\x85\x3c\x24\xda\xac\x39\xea\x14\xd1\x10\x48\x15\x4e\x43\x62\xe3\xa6\x0f\x7d\x3f\xa3\xab\x28\x16\xde\x44\x4a\xa3\x8d\xa2\xb9\x7d\xb0\x06\x35\x2f\xfc\x9e\xd7\xf3\xde\xfb\x91\xd6\xdb\x77\xa5\x65\x91\xd6\x04\x98\x30\x98\x28\x66\xd6\x21\xd1\x29\xed\x7d\xfc\xde\xed\xde\x7d\xcc\xae\xcf\x2f\x8f\xc7\xab\x8f\xb3\xee\x71\xf1\x96\x1e\xdc\x9e\xde\x88\x11\x7b\xc7\xe7\x3f\x4e\x97\xcb\xc1\x31\xfd\x98\x9e\x9e\xc6\xb3\x5f\x78\x3e\xc4\x64\x95\xce\x6e\x2e\x06\xdd\x69\x32\xbb\x1d\xfd\x94\xcd\xbf\xea\x0f\x04\x22\x25\xb5\x96\x8a\x25\x4c\x84\x84\x0a\x29\xd6\x99\x2c\x34\x69\xbc\xbf\xcc\x0d\x93\x82\x72\x30\x29\x66\xf8\xff\xf6\xd5\x2d\x59\x9e\xf3\x78\x3a\xbc\x7d\xf7\x79\xbf\xcb\x2f\xee\x66\x74\xfe\x69\xbe\xea\x71\xff\xe2\x87\x01\x4d\x8b\x65\x3e\x9e\xe2\xe7\xc5\xcd\xfb\xde\xf9\x01\x7e\x15\xbd\xe2\x97\xaf\x34\xbf\xde\x2f\x3e\x0c\x7e\xd6\xff\xbe\x98\x7d\xb9\x79\xbb\x3f\x10\x07\xea\xdb\x1e\x3f\x9b\xef\x73\xba\xa0\xe3\xaa\xb8\xaa\x50\xb4\x2b\xed\xb5\xae\xcf\x1e\x66\x79\xb6\xd3\xe5\xfd\x6c\x3c\x39\x3f\x1d\x9c\x31\xca\xa7\x59\xf1\xe9\xd3\x97\xd1\xfb\xe3\xef\xbf\xa8\x5c\xdd\x1d\x5c\xde\x4c\x6f\x7b\x1f\x46\x57\x57\xbd\xd9\xc1\x60\x78\xb7\xd2\xba\xbb\xbe\xb9\xbb\x34\x02\x73\x71\x76\x33\xfa\x81\x9e\x7f\x58\x8d\x9f\x76\xf9\x5e\xad\x57\x4b\x30\xeb\x1c\xeb\x63\xbc\x75\x96\x54\x0e\xce\x74\xad\xf0\xf8\xa4\xec\x2e\x89\x16\x58\x99\xcf\xaa\x44\x6c\xca\x4b\xa9\x32\xc9\x4d\x77\x11\x34\xc3\x90\x2c\x18\x2e\x73\xa9\x4c\xab\xa7\x2c\x59\x6c\xd2\x30\xc6\x05\x8b\xd0\x2d\x1f\xfe\x0e\x4c\x30\xc3\x28\x77\x75\x44\x39\x86\x5d\x8b\xf2\x0d\x17\xfa\x1d\xe7\xaf\x7b\x10\xcb\xa8\xc8\x50\x18\x78\xe3\x29\xa4\xf1\x7a\x6f\x5a\x88\xc8\x96\xf8\xde\x1b\xf8\x4f\xc7\x71\x00\x16\x54\xc1\x52\x8f\x94\x34\x32\x92\x1c\x42\xe0\x32\xa2\x56\xc2\xcb\x9b\x77\x21\xd4\xc9\x26\xf0\x0f\x20\x4b\x6d\xb3\x4e\xe0\xd0\x2e\xed\xea\xa8\x05\x04\x21\x08\x5c\xc2\x2d\x4e\xc6\x32\x9a\xa3\xd9\x6b\x61\xbf\xdd\x62\xa7\x52\x1b\x78\x0b\xc4\xc7\x28\x95\xe4\x4d\x85\xb0\xd4\x9e\x14\x19\x6a\x4d\x13\x84\x10\x1a\x53\x71\x63\x6b\xcd\xb2\x15\x39\x1f\x5f\x7e\xf6\x72\xdb\x77\xf7\x70\x81\xc2\x78\x31\x35\xb4\x46\x73\x00\xb8\x4c\xae\xf0\xae\x40\x6d\xf6\x6a\x9d\x37\x47\x76\xcb\xfe\x95\x32\xbf\x1e\x75\x3a\x8e\xe3\xfc\x6f\xa6\x73\x99\xbc\xd0\xf2\x9a\xe2\xc5\x76\x2f\x15\x33\x38\x94\xc9\xd3\x56\x3b\xbf\x96\xd2\xdb\x9a\x0c\xfc\xea\xea\xea\x74\x82\x89\x8c\xd7\xfd\xfa\x74\x8f\x0d\x8d\xe6\xb6\x99\x41\x24\x79\x91\x09\x0d\x52\x40\x26\x27\x8c\x23\x4c\xd6\x90\xd1\x39\x13\x09\x48\x81\x30\x2d\x38\xaf\xaa\xad\x6c\x01\x56\x45\x9a\x14\x15\xa4\x94\x4f\xeb\x8d\xaa\x07\xc4\x6c\x01\x11\xa7\x5a\x87\xc4\xd6\x2c\x65\x02\x95\x3b\xe5\x05\x8b\x37\x35\x4c\xa0\xac\xf7\x90\xe4\x34\x8e\x99\x48\x0e\xa1\x7b\x90\xaf\x8e\xaa\xaa\x6d\xa9\x2b\xb9\x2c\xdf\x3d\xc0\xe4\x6e\x16\xbb\xdd\x77\xd6\x64\x97\x27\xd5\xaa\xbc\x33\x2b\xe1\x7b\xd2\x39\x15\xc8\xa1\xfc\x74\x73\xc5\x32\xaa\xd6\x04\x2a\xb1\xc7\x72\xae\x8d\x11\x13\x49\x8d\xe3\x38\x41\xda\xbb\x2f\x50\xde\xc9\xe4\xa9\x1b\x3b\xed\x6d\x90\xfd\x98\x2d\x9e\x64\xb1\x09\x20\xc0\xe2\x90\xc4\x6c\xf1\x93\x92\x45\x5e\x23\x34\x71\x91\x0b\x54\x53\x2e\x97\x87\xb4\x30\xb2\xb1\xa6\xfe\x72\x82\x82\x6f\x00\x39\xd3\xc6\x4d\x2c\x44\x05\x98\xb4\xd1\x36\x7a\x2d\x4d\xbf\xe0\xf7\xd1\x9e\xb7\x74\x2a\xa5\x41\xb5\x0d\x07\xa7\x13\xe4\x0f\x92\xe7\x1a\x99\x1f\x42\xf7\x6f\x47\x5b\xbe\x80\x89\xbc\xd8\x34\x9e\x28\xc5\x68\x3e\x91\xab\xca\xc0\x28\x9d\x1f\x17\x46\x8e\x23\x25\x39\x27\x50\x6e\x62\x1c\x12\xa3\x0a\x24\xd0\x07\xbb\x09\xba\xdc\xdd\x90\xfa\x25\x6b\x63\xc3\xa4\x30\x46\x8a\x1a\xbc\x7a\x20\x1b\xab\x27\x46\xc0\xc4\x88\x6d\xa6\x0d\x9d\x30\x11\xe3\x2a\x24\xfb\x15\xff\xc4\x88\x13\x8e\x54\x3d\x8c\xf8\x94\x4b\x6a\x0e\x15\x4b\x52\xb3\x75\x24\xd0\x39\x15\x1b\xec\x84\xaf\xf3\x94\x45\x52\x40\xb3\x72\x15\x66\x72\x81\xae\x66\x89\x28\x2f\x80\x9c\x8a\x3e\x94\xf8\xdb\xa2\xa8\x4c\x6c\x20\x69\xdd\xf5\xfd\x94\xaa\xd7\xd9\x3d\x58\xd9\x8b\xe0\xec\xf8\x6a\xa7\xd1\x90\x51\x95\x30\xe1\x96\x4f\x87\x50\x9e\x27\x88\xe5\x52\x70\x49\xe3\xea\x32\x76\x69\xce\xdc\x4c\x46\x73\xcf\x72\xbf\xd8\xc9\x0d\x88\x4b\xb9\xd9\x7a\x59\x59\x03\x67\xc7\x57\x81\x4f\x1f\x97\xfd\x76\x59\xad\x9c\x4e\xeb\xdd\x37\xcf\x38\xe5\xa8\x0c\x94\x9f\x2e\x13\x53\xd9\x78\x1c\x33\x9d\x73\xba\x3e\x14\x52\x60\x15\x98\x52\xe8\x02\xb5\x46\x91\x20\xe9\xdf\x22\x8f\x64\x86\xde\x96\xeb\x29\x52\x78\x51\x67\xd1\x86\x9a\x72\x1c\x7a\x49\x63\xf9\x7d\xfa\xca\x29\x1a\xca\xb8\x7e\x5d\x3b\xe9\x77\x3a\xce\x76\xdf\x06\xc6\x30\x85\x11\xcd\x9f\x08\xdd\x8e\x40\xeb\x22\x8a\x50\xeb\xa6\x3d\x3a\x00\x81\x36\x4a\x8a\xa4\x5f\xd5\x48\x85\xba\x89\x08\xd4\x95\x10\xf8\xb5\x10\xb4\xa5\x54\x75\xaf\x6e\xc5\x3a\xce\x23\x57\x9c\xce\xa3\x7e\x26\xe8\x02\x04\x5d\xb8\x86\x4e\x34\x69\xd9\xc1\x59\x63\x71\x64\xd8\xc2\xb6\x8a\xe6\x24\xfd\xc5\xd0\xc9\x86\x0e\xec\x45\xe9\x1a\x99\x24\xd6\x65\x43\x27\xa4\x5f\xdf\xf0\xb6\x4a\x03\x9f\xb3\x7b\xa0\x0f\x41\x74\x2e\x85\xc6\xdd\x28\xd5\xde\x43\x18\xe7\x11\x4a\x8e\x4a\xb3\xdd\xa6\x8c\xaa\xad\x17\x98\x62\x67\x87\x1d\x00\x43\x99\x3c\x54\xae\x5a\xfa\xbd\xec\xd7\x91\x32\x74\xe2\x6e\x2e\xdc\x16\x55\x53\x1f\xad\xa8\xb5\x34\x6c\x4d\xc1\x94\xc6\x08\x4c\x40\x1d\xec\xad\x36\x40\x90\x2b\x2c\xf5\xd3\xb8\xc9\x71\xe0\xe7\x0a\x5b\x14\xed\x1c\x3f\x64\xdc\x84\x78\x17\xe5\xd3\x44\xb5\xd6\xb3\x4c\x4e\x9b\xa8\xc9\xc2\x6b\x78\x36\x4a\x3b\x69\x60\xb7\x47\x65\xa6\x5e\x43\x62\x15\x9e\x27\xd8\x71\x46\xea\x57\x3b\x5b\x6b\xa7\x73\x6f\xf5\x67\x98\x9f\x06\x2b\x8c\x0a\x3b\x01\xc3\x50\x26\xfa\x37\x0c\x4e\x43\x99\x34\x0d\xee\xb7\x0d\x4d\x16\xe9\x0f\x3a\x30\x0d\x65\xf2\x87\x98\x99\xda\xc1\xfe\xdd\xe7\xa5\xb2\xb5\xdd\x9b\x95\x5e\x38\x4d\xd4\xdf\xf6\x97\x8d\x5f\xfd\xb4\x09\xfc\xea\xbf\x75\xff\x1d\x00\x1c\x23\x8b\x53\xc5\x13\x00\x00")
 
 func tmplIndexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -105,7 +105,7 @@ func tmplIndexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "tmpl/index.html", size: 4760, mode: os.FileMode(420), modTime: time.Unix(1482669091, 0)}
+	info := bindataFileInfo{name: "tmpl/index.html", size: 5061, mode: os.FileMode(420), modTime: time.Unix(1792304574, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1,13 +1,18 @@
 package console
 
 import (
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"net/http"
 
 	"github.com/elazarl/go-bindata-assetfs"
 	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/har"
+	"github.com/vtrifonov/http-api-mock/journal"
 	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/utils"
 	"golang.org/x/net/websocket"
 )
 
@@ -15,8 +20,11 @@ import (
 type Dispatcher struct {
 	IP         string
 	Port       int
+	TLSPort    int
+	TLSConfig  *tls.Config
 	Mlog       chan definition.Match
 	Logs       chan string
+	Journal    *journal.Journal
 	clients    []*websocket.Conn
 	logClients []*websocket.Conn
 }
@@ -27,6 +35,18 @@ func (di *Dispatcher) consoleHandler(w http.ResponseWriter, r *http.Request) {
 	t.Execute(w, &di)
 }
 
+//harHandler exports the requests in the journal as HAR document
+func (di *Dispatcher) harHandler(w http.ResponseWriter, r *http.Request) {
+	if di.Journal == nil {
+		http.Error(w, "The request journal is disabled", http.StatusNotFound)
+		return
+	}
+	doc := har.Export(di.Journal.Find(journal.Filter{}), utils.GetServerAddress())
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="http-api-mock.har"`)
+	json.NewEncoder(w).Encode(doc)
+}
+
 func (di *Dispatcher) removeClient(i int) {
 	copy(di.clients[i:], di.clients[i+1:])
 	di.clients[len(di.clients)-1] = nil
@@ -103,14 +123,28 @@ func (di *Dispatcher) Start() {
 	http.Handle("/log", websocket.Handler(di.logHandler))
 	http.Handle("/js/", http.FileServer(&assetfs.AssetFS{Asset: Asset, AssetDir: AssetDir, AssetInfo: AssetInfo, Prefix: "tmpl"}))
 	http.Handle("/css/", http.FileServer(&assetfs.AssetFS{Asset: Asset, AssetDir: AssetDir, AssetInfo: AssetInfo, Prefix: "tmpl"}))
+	http.HandleFunc("/har", di.harHandler)
 	http.HandleFunc("/", di.consoleHandler)
 
 	go di.matchLogFanOut()
 	go di.logFanOut()
 
+	if di.TLSConfig != nil {
+		go di.startTLS()
+	}
+
 	addr := fmt.Sprintf("%s:%d", di.IP, di.Port)
 	err := http.ListenAndServe(addr, nil)
 	if err != nil {
 		logging.Fatalf("ListenAndServe: " + err.Error())
 	}
 }
+
+func (di *Dispatcher) startTLS() {
+	srv := &http.Server{Addr: fmt.Sprintf("%s:%d", di.IP, di.TLSPort), TLSConfig: di.TLSConfig}
+
+	err := srv.ListenAndServeTLS("", "")
+	if err != nil {
+		logging.Fatalf("ListenAndServeTLS: %s", err.Error())
+	}
+}
@@ -5,3 +5,11 @@ type ConfigReader interface {
 	CanRead(filename string) bool
 	Read(filename string) (Mock, error)
 }
+
+//MultipleConfigReader interface allows reading files which contain more than one mock definition, like API specifications.
+//The names of the returned mocks are prefixed with the file name.
+//The file content is read once and passed to all the readers, so CanRead should sniff it without parsing the whole file.
+type MultipleConfigReader interface {
+	CanRead(filename string, content []byte) bool
+	ReadMultiple(filename string, content []byte) ([]Mock, error)
+}
@@ -0,0 +1,41 @@
+package definition
+
+import "encoding/json"
+
+//The fault types which can be injected instead of the mock response
+const (
+	//FaultConnectionReset closes the connection with TCP reset before responding
+	FaultConnectionReset = "CONNECTION_RESET"
+	//FaultEmptyResponse closes the connection without sending anything
+	FaultEmptyResponse = "EMPTY_RESPONSE"
+	//FaultMalformedResponse sends an invalid HTTP response and closes the connection
+	FaultMalformedResponse = "MALFORMED_RESPONSE"
+	//FaultPartialResponse sends the headers and half of the body, then hangs until the duration passes or the client disconnects
+	FaultPartialResponse = "PARTIAL_RESPONSE"
+	//FaultTimeout hangs without responding until the duration passes or the client disconnects
+	FaultTimeout = "TIMEOUT"
+	//FaultRandomData sends random bytes and closes the connection
+	FaultRandomData = "RANDOM_DATA"
+)
+
+//Fault is a failure injected with the probability (0 to 1) instead of the mock response, the missing probability means always and 0 means never.
+//The duration in seconds plus the duration in milliseconds limits the hanging of the partial response and timeout faults, otherwise they hang until the client disconnects.
+type Fault struct {
+	Type        string   `json:"type"`
+	Probability *float64 `json:"probability"`
+	Duration    int      `json:"duration"`
+	DurationMs  int      `json:"durationMs"`
+}
+
+//gobFault is the fault without its gob methods
+type gobFault Fault
+
+//GobEncode keeps the zero probability when the mock is copied, as gob drops the pointers to zero values
+func (f Fault) GobEncode() ([]byte, error) {
+	return json.Marshal(gobFault(f))
+}
+
+//GobDecode reads the fault written by GobEncode
+func (f *Fault) GobDecode(data []byte) error {
+	return json.Unmarshal(data, (*gobFault)(f))
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"sync"
 
 	"github.com/fsnotify/fsnotify"
 	"github.com/vtrifonov/http-api-mock/logging"
@@ -18,17 +19,22 @@ var ErrNotFoundPath = errors.New("Configuration path not found")
 //NewFileDefinition file definition constructor
 func NewFileDefinition(path string, updatesCh chan []Mock) *FileDefinition {
 	return &FileDefinition{
-		Path:          path,
-		Updates:       updatesCh,
-		ConfigReaders: []ConfigReader{},
+		Path:                  path,
+		Updates:               updatesCh,
+		ConfigReaders:         []ConfigReader{},
+		MultipleConfigReaders: []MultipleConfigReader{},
 	}
 }
 
 //FileDefinition this struct contains the path of definition and some config readers
 type FileDefinition struct {
-	Path          string
-	Updates       chan []Mock
-	ConfigReaders []ConfigReader
+	Path                  string
+	Updates               chan []Mock
+	ConfigReaders         []ConfigReader
+	MultipleConfigReaders []MultipleConfigReader
+	// ignored are the files whose changes don't reload the mocks
+	ignored     map[string]bool
+	ignoredLock sync.RWMutex
 }
 
 //PrioritySort mock array sorted by priority
@@ -82,6 +88,23 @@ func (fd *FileDefinition) readMock(filename string) (Mock, error) {
 	return m, nil
 }
 
+//IgnoreFile makes the watcher skip the changes of the file, e.g. the recordings which are added to the router directly.
+//The file is still read with the other definitions when the mocks are reloaded.
+func (fd *FileDefinition) IgnoreFile(filename string) {
+	fd.ignoredLock.Lock()
+	defer fd.ignoredLock.Unlock()
+	if fd.ignored == nil {
+		fd.ignored = make(map[string]bool)
+	}
+	fd.ignored[filepath.Clean(filename)] = true
+}
+
+func (fd *FileDefinition) isIgnored(filename string) bool {
+	fd.ignoredLock.RLock()
+	defer fd.ignoredLock.RUnlock()
+	return fd.ignored[filepath.Clean(filename)]
+}
+
 //WatchDir start the watching process to detect any change on defintions
 func (fd *FileDefinition) WatchDir() {
 	watcher, err := fsnotify.NewWatcher()
@@ -113,6 +136,15 @@ func (fd *FileDefinition) WatchDir() {
 		for {
 			select {
 			case event := <-watcher.Events:
+				if fd.isIgnored(event.Name) {
+					continue
+				}
+				if event.Op&fsnotify.Create == fsnotify.Create {
+					// watch the new folders as well
+					if fileInfo, err := os.Stat(event.Name); err == nil && fileInfo.IsDir() {
+						watcher.Add(event.Name)
+					}
+				}
 				if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create || event.Op&fsnotify.Remove == fsnotify.Remove {
 
 					logging.Println("Changes detected in mock definitions")
@@ -131,6 +163,38 @@ func (fd *FileDefinition) AddConfigReader(reader ConfigReader) {
 	fd.ConfigReaders = append(fd.ConfigReaders, reader)
 }
 
+//AddMultipleConfigReader allows append readers of files containing more than one mock definition.
+//They are checked before the other config readers.
+func (fd *FileDefinition) AddMultipleConfigReader(reader MultipleConfigReader) {
+	fd.MultipleConfigReaders = append(fd.MultipleConfigReaders, reader)
+}
+
+//readMultipleMocks returns the mocks of the first multiple config reader which reads the file,
+//when the reader fails the file is left to the other config readers
+func (fd *FileDefinition) readMultipleMocks(file string) ([]Mock, bool) {
+	if len(fd.MultipleConfigReaders) == 0 {
+		return nil, false
+	}
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, false
+	}
+	for _, reader := range fd.MultipleConfigReaders {
+		if reader.CanRead(file, content) {
+			mockDefs, err := reader.ReadMultiple(file, content)
+			if err != nil {
+				logging.Printf("Invalid mock definitions in: %s %s\n", file, err.Error())
+				return nil, false
+			}
+			for i := range mockDefs {
+				mockDefs[i].Name = filepath.Base(file) + ":" + mockDefs[i].Name
+			}
+			return mockDefs, true
+		}
+	}
+	return nil, false
+}
+
 //ReadMocksDefinition reads all definitions and return an array of valid mocks
 func (fd *FileDefinition) ReadMocksDefinition() []Mock {
 
@@ -140,6 +204,10 @@ func (fd *FileDefinition) ReadMocksDefinition() []Mock {
 
 	mocks := []Mock{}
 	for _, file := range fd.getConfigFiles(fd.Path) {
+		if mockDefs, found := fd.readMultipleMocks(file); found {
+			mocks = append(mocks, mockDefs...)
+			continue
+		}
 		for _, reader := range fd.ConfigReaders {
 			if reader.CanRead(file) {
 				if mockDef, err := reader.Read(file); err == nil {
@@ -154,7 +222,7 @@ func (fd *FileDefinition) ReadMocksDefinition() []Mock {
 
 	}
 
-	sort.Sort(PrioritySort(mocks))
+	sort.Stable(PrioritySort(mocks))
 
 	return mocks
 }
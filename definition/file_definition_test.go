@@ -0,0 +1,47 @@
+package definition
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+//failingReader takes every file and fails reading it
+type failingReader struct {
+}
+
+func (r failingReader) CanRead(filename string, content []byte) bool {
+	return true
+}
+
+func (r failingReader) ReadMultiple(filename string, content []byte) ([]Mock, error) {
+	return nil, errors.New("not a specification")
+}
+
+func TestFileDefinition_FailedMultipleReaderFallsBack(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"request": {"method": "GET", "path": "/spec.json"}, "response": {"statusCode": 200, "body": "{\"openapi\": \"3.0.1\"}"}}`
+	if err := ioutil.WriteFile(filepath.Join(dir, "spec.json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fd := NewFileDefinition(dir, nil)
+	fd.AddMultipleConfigReader(failingReader{})
+	fd.AddConfigReader(JSONReader{})
+	mocks := fd.ReadMocksDefinition()
+	if len(mocks) != 1 || mocks[0].Name != "spec.json" || mocks[0].Request.Path != "/spec.json" {
+		t.Error("The file should be read by the single mock readers when the multiple reader fails", mocks)
+	}
+}
+
+func TestFileDefinition_IgnoreFile(t *testing.T) {
+	fd := NewFileDefinition("config", nil)
+	fd.IgnoreFile("config/recordings/../get-users.json")
+	if !fd.isIgnored("config/get-users.json") {
+		t.Error("The ignored file should be skipped")
+	}
+	if fd.isIgnored("config/hello.json") {
+		t.Error("The other files should not be skipped")
+	}
+}
@@ -0,0 +1,15 @@
+package definition
+
+import "encoding/json"
+
+//GraphQL matches the GraphQL requests by operation name, query document and variables.
+//The queries are compared in their normalized form, so the formatting, the commas and the comments don't matter.
+//The schema is the path of a SDL file relative to the config path, it is used for validating the requests and the responses
+//and for generating the response when the mock has no body.
+type GraphQL struct {
+	OperationName              string          `json:"operationName"`
+	Query                      string          `json:"query"`
+	Variables                  json.RawMessage `json:"variables"`
+	VariablesIgnoreExtraFields bool            `json:"variablesIgnoreExtraFields"`
+	Schema                     string          `json:"schema"`
+}
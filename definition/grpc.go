@@ -0,0 +1,12 @@
+package definition
+
+//GRPCMethod is the request method of the gRPC call mocks, their path is the full method name e.g. "/helloworld.Greeter/SayHello"
+const GRPCMethod = "GRPC"
+
+//GRPCStatus contains the status and the trailing metadata of a gRPC call response.
+//The code is either the status code name like "NOT_FOUND" or its number, the default one is "OK".
+type GRPCStatus struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Trailers Values `json:"trailers"`
+}
@@ -1,5 +1,7 @@
 package definition
 
+import "encoding/json"
+
 type Values map[string][]string
 
 type Cookies map[string]string
@@ -9,16 +11,47 @@ type HttpHeaders struct {
 	Cookies Cookies `json:"cookies"`
 }
 
+//ClientCertificate contains the details of the TLS client certificate presented with the request.
+//The fingerprint is the SHA-256 hash of the certificate as lowercase hex string.
+type ClientCertificate struct {
+	Subject     string   `json:"subject"`
+	Issuer      string   `json:"issuer"`
+	SANs        []string `json:"sans"`
+	Fingerprint string   `json:"fingerprint"`
+}
+
 type Request struct {
 	Method                string `json:"method"`
 	Path                  string `json:"path"`
 	QueryStringParameters Values `json:"queryStringParameters"`
 	HttpHeaders
-	Body string `json:"body"`
+	QueryStringMatchers       ValueMatchers      `json:"queryStringMatchers"`
+	HeaderMatchers            ValueMatchers      `json:"headerMatchers"`
+	CookieMatchers            ValueMatchers      `json:"cookieMatchers"`
+	TLS                       *ClientCertificate `json:"tls,omitempty"`
+	TLSMatchers               ValueMatchers      `json:"tlsMatchers"`
+	Body                      string             `json:"body"`
+	BodyJSON                  json.RawMessage    `json:"bodyJson"`
+	BodyJSONIgnoreExtraFields bool               `json:"bodyJsonIgnoreExtraFields"`
+	BodyJSONPath              ValueMatchers      `json:"bodyJsonPath"`
+	GraphQL                   *GraphQL           `json:"graphql,omitempty"`
+	SOAPAction                string             `json:"soapAction"`
+	BodyXPath                 ValueMatchers      `json:"bodyXPath"`
+	XMLNamespaces             map[string]string  `json:"xmlNamespaces"`
+	//Host, Scheme and RemoteAddr are set for the received requests only, they are not matched
+	Host       string `json:"host,omitempty"`
+	Scheme     string `json:"scheme,omitempty"`
+	RemoteAddr string `json:"remoteAddr,omitempty"`
 }
 
 type Response struct {
 	StatusCode int `json:"statusCode"`
 	HttpHeaders
-	Body string `json:"body"`
+	Body      string      `json:"body"`
+	GRPC      *GRPCStatus `json:"grpc,omitempty"`
+	SOAPFault *SOAPFault  `json:"soapFault,omitempty"`
+	//Chunks and Events are streamed after the body, the connection is dropped after DisconnectAfter of them when it is positive
+	Chunks          []ResponseChunk `json:"chunks,omitempty"`
+	Events          []SSEEvent      `json:"events,omitempty"`
+	DisconnectAfter int             `json:"disconnectAfter,omitempty"`
 }
@@ -0,0 +1,23 @@
+package definition
+
+//The distributions of the random response delays
+const (
+	LatencyFixed     = "fixed"
+	LatencyUniform   = "uniform"
+	LatencyNormal    = "normal"
+	LatencyLogNormal = "lognormal"
+)
+
+//Latency is the response delay in milliseconds, either fixed or drawn from a distribution on every request.
+//The uniform delay is between min and max, the normal one has mean and standard deviation and the log-normal one has median and sigma (the standard deviation of the logarithm).
+//The random delays are limited to the max when it is positive and they are never negative.
+type Latency struct {
+	Distribution string  `json:"distribution"`
+	DelayMs      int     `json:"delayMs"`
+	MinMs        int     `json:"minMs"`
+	MaxMs        int     `json:"maxMs"`
+	MeanMs       float64 `json:"meanMs"`
+	StdDevMs     float64 `json:"stdDevMs"`
+	MedianMs     float64 `json:"medianMs"`
+	Sigma        float64 `json:"sigma"`
+}
@@ -2,12 +2,16 @@ package definition
 
 //Result contains the match result and the failing matches with different mocks and the reason or the fail.
 type Result struct {
-	Found  bool              `json:"match"`
-	Errors map[string]string `json:"errors"`
+	Found      bool              `json:"match"`
+	Errors     map[string]string `json:"errors"`
+	NearMisses []NearMiss        `json:"nearMisses,omitempty"`
+	Violations []string          `json:"violations,omitempty"`
+	Fault      string            `json:"fault,omitempty"`
 }
 
 //Match contains the whole information about the request match. The http request, the final response received and the matching result.
 type Match struct {
+	MockName string   `json:"mock"`
 	Request  Request  `json:"request"`
 	Response Response `json:"response"`
 	Result   Result   `json:"result"`
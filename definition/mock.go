@@ -1,12 +1,26 @@
 package definition
 
 type Control struct {
-	Priority     int    `json:"priority"`
-	Delay        int    `json:"delay"`
-	Crazy        bool   `json:"crazy"`
-	ProxyBaseURL string `json:"proxyBaseURL"`
+	Priority       int      `json:"priority"`
+	Delay          int      `json:"delay"`
+	Crazy          bool     `json:"crazy"`
+	ProxyBaseURL   string   `json:"proxyBaseURL"`
+	Scenario       string   `json:"scenario"`
+	RequiredState  string   `json:"requiredState"`
+	NewState       string   `json:"newState"`
+	CycleResponses bool     `json:"cycleResponses"`
+	Record         bool     `json:"record"`
+	Faults         []Fault  `json:"faults,omitempty"`
+	Latency        *Latency `json:"latency,omitempty"`
+	BytesPerSecond int      `json:"bytesPerSecond,omitempty"`
+	TemplateEngine string   `json:"templateEngine,omitempty"`
+	//FakeSeed makes the fake data deterministic, it allows request vars e.g. "{{request.path.id}}"
+	FakeSeed string `json:"fakeSeed,omitempty"`
 }
 
+//TemplateEngineGo renders the response and the persist fields of the mock as Go text/template instead of filling the vars
+const TemplateEngineGo = "go"
+
 type Actions map[string]string
 type Requests []Request
 
@@ -23,13 +37,24 @@ type Notify struct {
 	Http Requests       `json:"http"`
 }
 
+//OpenAPI links the mock to an OpenAPI or Swagger operation, the requests are validated against it.
+//The spec path is relative to the config path and the operation is either the operation id or the method and path template e.g. "GET /pets/{petId}".
+type OpenAPI struct {
+	Spec           string `json:"spec"`
+	Operation      string `json:"operation"`
+	SkipValidation bool   `json:"skipValidation"`
+}
+
 //Mock contains the user mock definition
 type Mock struct {
 	Name        string
-	Description string   `json:"description"`
-	Request     Request  `json:"request"`
-	Response    Response `json:"response"`
-	Persist     Persist  `json:"persist"`
-	Notify      Notify   `json:"notify"`
-	Control     Control  `json:"control"`
+	Description string     `json:"description"`
+	Request     Request    `json:"request"`
+	Response    Response   `json:"response"`
+	Responses   []Response `json:"responses"`
+	Persist     Persist    `json:"persist"`
+	Notify      Notify     `json:"notify"`
+	Control     Control    `json:"control"`
+	OpenAPI     *OpenAPI   `json:"openapi,omitempty"`
+	WebSocket   *WebSocket `json:"websocket,omitempty"`
 }
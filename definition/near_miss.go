@@ -0,0 +1,29 @@
+package definition
+
+//FieldDiff describes a request field which doesn't match the mock definition.
+type FieldDiff struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+//NearMiss describes how close a request is to match a mock definition.
+//The score is the part of the mock checks the request passes, from 0 to 1.
+type NearMiss struct {
+	MockName string      `json:"mock"`
+	Score    float64     `json:"score"`
+	Diffs    []FieldDiff `json:"diffs"`
+}
+
+//NearMissSort sorts the near misses by score, the closest first.
+type NearMissSort []NearMiss
+
+func (s NearMissSort) Len() int {
+	return len(s)
+}
+func (s NearMissSort) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s NearMissSort) Less(i, j int) bool {
+	return s[i].Score > s[j].Score
+}
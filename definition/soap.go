@@ -0,0 +1,11 @@
+package definition
+
+//SOAPFault is a short definition of a SOAP fault response, the envelope is generated from it.
+//The version is either "1.1" (default) or "1.2", the code is a fault code like "Client" or "Server" and the detail is a raw XML fragment.
+type SOAPFault struct {
+	Version string `json:"version"`
+	Code    string `json:"code"`
+	Reason  string `json:"reason"`
+	Actor   string `json:"actor"`
+	Detail  string `json:"detail"`
+}
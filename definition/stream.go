@@ -0,0 +1,19 @@
+package definition
+
+//ResponseChunk is a part of a streamed response body, it is written and flushed after the delay in seconds plus the delay in milliseconds
+type ResponseChunk struct {
+	Body    string `json:"body"`
+	Delay   int    `json:"delay"`
+	DelayMs int    `json:"delayMs"`
+}
+
+//SSEEvent is a server-sent event of a streamed response, it is written and flushed after the delay in seconds plus the delay in milliseconds.
+//The multiline data is sent as multiple data fields and the retry is the reconnection time in milliseconds.
+type SSEEvent struct {
+	ID      string `json:"id"`
+	Event   string `json:"event"`
+	Data    string `json:"data"`
+	Retry   int    `json:"retry"`
+	Delay   int    `json:"delay"`
+	DelayMs int    `json:"delayMs"`
+}
@@ -0,0 +1,87 @@
+package definition
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+//ValueMatcher describes the expected value of a request field.
+//It can be defined as a plain value for exact match or as an object with match operators.
+//When no operator is set the field only needs to be present, e.g. for the JSON null.
+type ValueMatcher struct {
+	EqualTo    string `json:"equalTo"`    // the value should be equal to this one
+	NotEqualTo string `json:"notEqualTo"` // the value should differ from this one
+	Matches    string `json:"matches"`    // the value should match this regex
+	Contains   string `json:"contains"`   // the value should contain this string
+	Prefix     string `json:"prefix"`     // the value should start with this string
+	Absent     bool   `json:"absent"`     // the field should be missing
+	Empty      bool   `json:"empty"`      // the value should be empty, it is set by the empty string equalTo
+}
+
+//ValueMatchers holds the value matchers of a request section by field name.
+type ValueMatchers map[string]ValueMatcher
+
+//UnmarshalJSON allows defining the matcher as plain string, number or boolean value for exact match.
+//The null means no value, so the field only needs to be present, while the empty string should be equal to the value.
+func (vm *ValueMatcher) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*vm = ValueMatcher{}
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &vm.EqualTo); err != nil {
+			return err
+		}
+		vm.Empty = vm.EqualTo == ""
+		return nil
+	}
+	if len(data) > 0 && data[0] != '{' {
+		vm.EqualTo = string(data)
+		return nil
+	}
+	type plainValueMatcher ValueMatcher
+	if err := json.Unmarshal(data, (*plainValueMatcher)(vm)); err != nil {
+		return err
+	}
+	// the empty equalTo can't be told from the missing one after decoding
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if equalTo, ok := fields["equalTo"]; ok && string(bytes.TrimSpace(equalTo)) == `""` {
+		vm.Empty = true
+	}
+	return nil
+}
+
+//String describes the matcher operators, it is used for reporting the near misses.
+func (vm ValueMatcher) String() string {
+	if vm.Absent {
+		return "absent"
+	}
+	var operators []string
+	if vm.Empty {
+		operators = append(operators, "empty")
+	}
+	if vm.EqualTo != "" {
+		operators = append(operators, vm.EqualTo)
+	}
+	if vm.NotEqualTo != "" {
+		operators = append(operators, "not equal to "+vm.NotEqualTo)
+	}
+	if vm.Matches != "" {
+		operators = append(operators, "matches "+vm.Matches)
+	}
+	if vm.Contains != "" {
+		operators = append(operators, "contains "+vm.Contains)
+	}
+	if vm.Prefix != "" {
+		operators = append(operators, "starts with "+vm.Prefix)
+	}
+	if len(operators) == 0 {
+		return "present"
+	}
+	return strings.Join(operators, " and ")
+}
@@ -0,0 +1,42 @@
+package definition
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValueMatcher_UnmarshalJSON(t *testing.T) {
+	tests := map[string]ValueMatcher{
+		`"abc"`:                             {EqualTo: "abc"},
+		`12`:                                {EqualTo: "12"},
+		`null`:                              {},
+		`""`:                                {Empty: true},
+		`{"equalTo": ""}`:                   {Empty: true},
+		`{"empty": true}`:                   {Empty: true},
+		`{"prefix": "Bearer "}`:             {Prefix: "Bearer "},
+		`{"equalTo": "a", "absent": false}`: {EqualTo: "a"},
+	}
+	for data, expected := range tests {
+		var vm ValueMatcher
+		if err := json.Unmarshal([]byte(data), &vm); err != nil {
+			t.Error(data, err)
+			continue
+		}
+		if vm != expected {
+			t.Errorf("The matcher %s should be %+v, got %+v", data, expected, vm)
+		}
+	}
+}
+
+func TestValueMatcher_UnmarshalNullInMatchers(t *testing.T) {
+	var matchers ValueMatchers
+	if err := json.Unmarshal([]byte(`{"a": null, "b": ""}`), &matchers); err != nil {
+		t.Fatal(err)
+	}
+	if matchers["a"].String() != "present" {
+		t.Error("The null matcher should only need a present value", matchers["a"])
+	}
+	if matchers["b"].String() != "empty" {
+		t.Error("The empty string matcher should need an empty value", matchers["b"])
+	}
+}
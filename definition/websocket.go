@@ -0,0 +1,47 @@
+package definition
+
+import "encoding/json"
+
+//WebSocket is the script run on the connections upgraded by the mock.
+//The messages are sent on connect, as replies to the matching incoming messages and periodically.
+//The delays and intervals are in seconds, the ones in milliseconds are added to them.
+type WebSocket struct {
+	OnConnect []WebSocketMessage  `json:"onConnect"`
+	Replies   []WebSocketReply    `json:"replies"`
+	Periodic  []WebSocketPeriodic `json:"periodic"`
+	Close     *WebSocketClose     `json:"close,omitempty"`
+}
+
+//WebSocketMessage is a text message sent after the delay, the body allows vars
+type WebSocketMessage struct {
+	Body    string `json:"body"`
+	Delay   int    `json:"delay"`
+	DelayMs int    `json:"delayMs"`
+}
+
+//WebSocketReply is sent when an incoming message matches the body glob pattern, the JSON body or the JSONPath matchers.
+//The reply without any of them matches all the messages.
+type WebSocketReply struct {
+	Body                      string             `json:"body"`
+	BodyJSON                  json.RawMessage    `json:"bodyJson"`
+	BodyJSONIgnoreExtraFields bool               `json:"bodyJsonIgnoreExtraFields"`
+	BodyJSONPath              ValueMatchers      `json:"bodyJsonPath"`
+	Messages                  []WebSocketMessage `json:"messages"`
+	Close                     *WebSocketClose    `json:"close,omitempty"`
+}
+
+//WebSocketPeriodic is a message sent every interval, count limits the number of the messages when it is positive
+type WebSocketPeriodic struct {
+	Body       string `json:"body"`
+	Interval   int    `json:"interval"`
+	IntervalMs int    `json:"intervalMs"`
+	Count      int    `json:"count"`
+}
+
+//WebSocketClose closes the connection with the code and reason after the delay
+type WebSocketClose struct {
+	Code    int    `json:"code"`
+	Reason  string `json:"reason"`
+	Delay   int    `json:"delay"`
+	DelayMs int    `json:"delayMs"`
+}
@@ -0,0 +1,101 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vtrifonov/http-api-mock/openapi"
+)
+
+//ErrInvalidQuery when the response can't be generated, because the query is not valid against the schema
+var ErrInvalidQuery = errors.New("GraphQL query not valid against the schema")
+
+//Generate builds a response body with data for every field selected by the request operation.
+//The values are fake data tags, so they differ on every request.
+func (s *Schemas) Generate(file string, request Request) (string, error) {
+	schema, err := s.Get(file)
+	if err != nil {
+		return "", err
+	}
+	_, op, violations := loadOperation(schema, request)
+	if len(violations) > 0 {
+		return "", ErrInvalidQuery
+	}
+	root := rootType(schema, op)
+	if root == nil {
+		return "", ErrOperationNotFound
+	}
+	return `{"data": ` + generateObject(schema, op.SelectionSet, root) + "}", nil
+}
+
+func generateObject(schema *ast.Schema, selectionSet ast.SelectionSet, objectType *ast.Definition) string {
+	// the abstract types are generated as their first possible type
+	if objectType.IsAbstractType() {
+		possibleTypes := schema.GetPossibleTypes(objectType)
+		if len(possibleTypes) == 0 {
+			return "null"
+		}
+		objectType = possibleTypes[0]
+	}
+	groups := collectFields(schema, selectionSet, objectType)
+	fields := make([]string, len(groups))
+	for i, group := range groups {
+		value := marshal(objectType.Name)
+		if group.field.Name != "__typename" {
+			value = generateValue(schema, group, fieldType(objectType, group.field.Name))
+		}
+		fields[i] = marshal(group.key) + ": " + value
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+func generateValue(schema *ast.Schema, group *fieldGroup, t *ast.Type) string {
+	if t == nil {
+		return "null"
+	}
+	if t.Elem != nil {
+		return "[" + generateValue(schema, group, t.Elem) + "]"
+	}
+	def := schema.Types[t.NamedType]
+	if def == nil {
+		return "null"
+	}
+	switch def.Kind {
+	case ast.Object, ast.Interface, ast.Union:
+		return generateObject(schema, group.selectionSet, def)
+	case ast.Enum:
+		if len(def.EnumValues) > 0 {
+			return marshal(def.EnumValues[0].Name)
+		}
+		return "null"
+	}
+	switch def.Name {
+	case "Int":
+		return "{{fake.Int(1000)}}"
+	case "Float":
+		return "{{fake.Float(1000)}}"
+	case "Boolean":
+		return "true"
+	case "ID":
+		return marshal("{{fake.UUID}}")
+	}
+	return marshal(openapi.FakeStringTag(group.field.Name))
+}
+
+//fieldType returns the type of the object field, it is nil for unknown fields
+func fieldType(objectType *ast.Definition, name string) *ast.Type {
+	if field := objectType.Fields.ForName(name); field != nil {
+		return field.Type
+	}
+	return nil
+}
+
+func marshal(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
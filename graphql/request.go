@@ -0,0 +1,82 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/formatter"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+var (
+	commentsRegex   = regexp.MustCompile(`#[^\n\r]*`)
+	separatorsRegex = regexp.MustCompile(`[\s,]+`)
+)
+
+//Request is a GraphQL request, it can be sent as JSON body, as application/graphql body or in the query string of a GET request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+//ParseRequest extracts the GraphQL request from the HTTP request, it returns false if there is no query.
+func ParseRequest(req *definition.Request) (Request, bool) {
+	request := Request{}
+	if values := req.QueryStringParameters["query"]; len(values) > 0 {
+		request.Query = values[0]
+		if values := req.QueryStringParameters["operationName"]; len(values) > 0 {
+			request.OperationName = values[0]
+		}
+		if values := req.QueryStringParameters["variables"]; len(values) > 0 {
+			json.Unmarshal([]byte(values[0]), &request.Variables)
+		}
+	} else if err := json.Unmarshal([]byte(req.Body), &request); err != nil {
+		if !isGraphQLContentType(req.Headers) {
+			return request, false
+		}
+		request = Request{Query: req.Body}
+	}
+	if strings.TrimSpace(request.Query) == "" {
+		return request, false
+	}
+	if request.OperationName == "" {
+		request.OperationName = singleOperationName(request.Query)
+	}
+	return request, true
+}
+
+//NormalizeQuery returns the query document in a canonical form, so the formatting, the commas and the comments don't matter.
+//The queries which can't be parsed are only stripped from the comments and the extra whitespace.
+func NormalizeQuery(query string) string {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		query = commentsRegex.ReplaceAllString(query, "")
+		return strings.TrimSpace(separatorsRegex.ReplaceAllString(query, " "))
+	}
+	var buf bytes.Buffer
+	formatter.NewFormatter(&buf, formatter.WithIndent(" ")).FormatQueryDocument(doc)
+	return strings.TrimSpace(separatorsRegex.ReplaceAllString(buf.String(), " "))
+}
+
+//singleOperationName returns the name of the operation when the document contains only one
+func singleOperationName(query string) string {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil || len(doc.Operations) != 1 {
+		return ""
+	}
+	return doc.Operations[0].Name
+}
+
+func isGraphQLContentType(headers definition.Values) bool {
+	for name, values := range headers {
+		if strings.EqualFold(name, "Content-Type") && len(values) > 0 {
+			return strings.HasPrefix(strings.ToLower(values[0]), "application/graphql")
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+//ValidateResponse returns the differences between the response body data and the fields selected by the request operation.
+//The responses with errors only are not validated.
+func (s *Schemas) ValidateResponse(file string, request Request, body string) ([]string, error) {
+	schema, err := s.Get(file)
+	if err != nil {
+		return nil, err
+	}
+	_, op, violations := loadOperation(schema, request)
+	if len(violations) > 0 {
+		return nil, ErrInvalidQuery
+	}
+	response := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		return []string{"response: should be a JSON object"}, nil
+	}
+	data, ok := response["data"]
+	if !ok {
+		if _, hasErrors := response["errors"]; hasErrors {
+			return nil, nil
+		}
+		return []string{"data: is required"}, nil
+	}
+	if data == nil {
+		return nil, nil
+	}
+	root := rootType(schema, op)
+	if root == nil {
+		return nil, ErrOperationNotFound
+	}
+	return validateObject(schema, op.SelectionSet, root, data, "data"), nil
+}
+
+func validateObject(schema *ast.Schema, selectionSet ast.SelectionSet, objectType *ast.Definition, data interface{}, path string) []string {
+	object, ok := data.(map[string]interface{})
+	if !ok {
+		return []string{fmt.Sprintf("%s: should be an object", path)}
+	}
+	if objectType.IsAbstractType() {
+		typeName, _ := object["__typename"].(string)
+		concrete := schema.Types[typeName]
+		if concrete == nil {
+			// without __typename only the fields common for all the possible types can be checked
+			return nil
+		}
+		objectType = concrete
+	}
+
+	violations := []string{}
+	for _, group := range collectFields(schema, selectionSet, objectType) {
+		fieldPath := path + "." + group.key
+		value, present := object[group.key]
+		if !present {
+			violations = append(violations, fmt.Sprintf("%s: is missing", fieldPath))
+			continue
+		}
+		if group.field.Name == "__typename" {
+			continue
+		}
+		violations = append(violations, validateValue(schema, group, fieldType(objectType, group.field.Name), value, fieldPath)...)
+	}
+	return violations
+}
+
+func validateValue(schema *ast.Schema, group *fieldGroup, t *ast.Type, value interface{}, path string) []string {
+	if t == nil {
+		return nil
+	}
+	if value == nil {
+		if t.NonNull {
+			return []string{fmt.Sprintf("%s: should not be null", path)}
+		}
+		return nil
+	}
+	if t.Elem != nil {
+		items, ok := value.([]interface{})
+		if !ok {
+			return []string{fmt.Sprintf("%s: should be a list", path)}
+		}
+		violations := []string{}
+		for i, item := range items {
+			violations = append(violations, validateValue(schema, group, t.Elem, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return violations
+	}
+
+	def := schema.Types[t.NamedType]
+	if def == nil {
+		return nil
+	}
+	switch def.Kind {
+	case ast.Object, ast.Interface, ast.Union:
+		return validateObject(schema, group.selectionSet, def, value, path)
+	case ast.Enum:
+		name, _ := value.(string)
+		if def.EnumValues.ForName(name) == nil {
+			return []string{fmt.Sprintf("%s: should be a %s value", path, def.Name)}
+		}
+		return nil
+	}
+	if !isScalarValue(def.Name, value) {
+		return []string{fmt.Sprintf("%s: should be %s", path, def.Name)}
+	}
+	return nil
+}
+
+//isScalarValue checks the values of the built-in scalars, the custom scalars accept any value
+func isScalarValue(scalar string, value interface{}) bool {
+	switch scalar {
+	case "Int":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "Float":
+		_, ok := value.(float64)
+		return ok
+	case "String":
+		_, ok := value.(string)
+		return ok
+	case "Boolean":
+		_, ok := value.(bool)
+		return ok
+	case "ID":
+		_, isString := value.(string)
+		number, isNumber := value.(float64)
+		return isString || (isNumber && number == math.Trunc(number))
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+//ErrOperationNotFound when the document doesn't contain the requested operation
+var ErrOperationNotFound = errors.New("GraphQL operation not found")
+
+//Schemas loads the GraphQL schemas of the mocks, they are reloaded when the files change.
+type Schemas struct {
+	ConfigPath string
+	schemas    map[string]cachedSchema
+	sync.Mutex
+}
+
+type cachedSchema struct {
+	schema  *ast.Schema
+	modTime time.Time
+}
+
+//NewSchemas returns a schema loader resolving the files relative to the config path
+func NewSchemas(configPath string) *Schemas {
+	return &Schemas{ConfigPath: configPath, schemas: make(map[string]cachedSchema)}
+}
+
+//Get returns the schema defined in the SDL file
+func (s *Schemas) Get(file string) (*ast.Schema, error) {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(s.ConfigPath, file)
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if cached, ok := s.schemas[file]; ok && cached.modTime.Equal(info.ModTime()) {
+		return cached.schema, nil
+	}
+	buf, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := gqlparser.LoadSchema(&ast.Source{Name: file, Input: string(buf)})
+	if err != nil {
+		return nil, err
+	}
+	s.schemas[file] = cachedSchema{schema: schema, modTime: info.ModTime()}
+	return schema, nil
+}
+
+//Validate returns the errors of the request query and variables against the schema.
+func (s *Schemas) Validate(file string, request Request) ([]string, error) {
+	schema, err := s.Get(file)
+	if err != nil {
+		return nil, err
+	}
+	_, op, violations := loadOperation(schema, request)
+	if len(violations) > 0 || op == nil {
+		return violations, nil
+	}
+	if _, err := validator.VariableValues(schema, op, request.Variables); err != nil {
+		return []string{err.Error()}, nil
+	}
+	return nil, nil
+}
+
+//loadOperation parses and validates the query, it returns the requested operation when the query is valid
+func loadOperation(schema *ast.Schema, request Request) (*ast.QueryDocument, *ast.OperationDefinition, []string) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: request.Query})
+	if err != nil {
+		return nil, nil, []string{err.Error()}
+	}
+	if errs := validator.Validate(schema, doc); len(errs) > 0 {
+		violations := make([]string, len(errs))
+		for i, err := range errs {
+			violations[i] = err.Error()
+		}
+		return nil, nil, violations
+	}
+	op := doc.Operations.ForName(request.OperationName)
+	if op == nil {
+		return nil, nil, []string{fmt.Sprintf("%s: %s", ErrOperationNotFound.Error(), request.OperationName)}
+	}
+	return doc, op, nil
+}
+
+//rootType returns the schema type of the operation
+func rootType(schema *ast.Schema, op *ast.OperationDefinition) *ast.Definition {
+	switch op.Operation {
+	case ast.Mutation:
+		return schema.Mutation
+	case ast.Subscription:
+		return schema.Subscription
+	}
+	return schema.Query
+}
+
+//fieldGroup is a response field with the selection sets of all the fields merged into it
+type fieldGroup struct {
+	key          string
+	field        *ast.Field
+	selectionSet ast.SelectionSet
+}
+
+//collectFields returns the fields selected for the object type, including the ones from the matching fragments.
+func collectFields(schema *ast.Schema, selectionSet ast.SelectionSet, objectType *ast.Definition) []*fieldGroup {
+	groups := []*fieldGroup{}
+	indexes := map[string]int{}
+	var collect func(selectionSet ast.SelectionSet)
+	collect = func(selectionSet ast.SelectionSet) {
+		for _, selection := range selectionSet {
+			switch selection := selection.(type) {
+			case *ast.Field:
+				key := selection.Alias
+				if key == "" {
+					key = selection.Name
+				}
+				if i, ok := indexes[key]; ok {
+					groups[i].selectionSet = append(groups[i].selectionSet, selection.SelectionSet...)
+					continue
+				}
+				indexes[key] = len(groups)
+				groups = append(groups, &fieldGroup{key: key, field: selection, selectionSet: append(ast.SelectionSet{}, selection.SelectionSet...)})
+			case *ast.InlineFragment:
+				if appliesTo(schema, selection.TypeCondition, objectType) {
+					collect(selection.SelectionSet)
+				}
+			case *ast.FragmentSpread:
+				if selection.Definition != nil && appliesTo(schema, selection.Definition.TypeCondition, objectType) {
+					collect(selection.Definition.SelectionSet)
+				}
+			}
+		}
+	}
+	collect(selectionSet)
+	return groups
+}
+
+//appliesTo checks whether the fragment type condition includes the object type
+func appliesTo(schema *ast.Schema, typeCondition string, objectType *ast.Definition) bool {
+	if typeCondition == "" || typeCondition == objectType.Name {
+		return true
+	}
+	condition := schema.Types[typeCondition]
+	if condition == nil || !condition.IsAbstractType() {
+		return false
+	}
+	for _, possible := range schema.GetPossibleTypes(condition) {
+		if possible.Name == objectType.Name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+const schemaFile = "graphql/schema.graphql"
+
+func TestParseRequest(t *testing.T) {
+	req := &definition.Request{Body: `{"query": "query GetUser { user(id: 1) { id } }", "variables": {"id": "1"}}`}
+	request, found := ParseRequest(req)
+	if !found || request.OperationName != "GetUser" || request.Variables["id"] != "1" {
+		t.Error("The JSON requests should be parsed and the single operation name used", request)
+	}
+
+	req = &definition.Request{Body: "{ users { id } }", HttpHeaders: definition.HttpHeaders{Headers: definition.Values{"content-type": []string{"application/graphql"}}}}
+	if request, found := ParseRequest(req); !found || request.Query != "{ users { id } }" {
+		t.Error("The application/graphql requests should be parsed", request)
+	}
+
+	req = &definition.Request{QueryStringParameters: definition.Values{"query": []string{"query A { users { id } } query B { users { name } }"}, "operationName": []string{"B"}}}
+	if request, found := ParseRequest(req); !found || request.OperationName != "B" {
+		t.Error("The GET requests should be parsed", request)
+	}
+
+	if _, found := ParseRequest(&definition.Request{Body: `{"name": "John"}`}); found {
+		t.Error("The requests without query should not be parsed")
+	}
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	a := NormalizeQuery("query GetUser($id: ID!) {\n  user(id: $id) {\n    id,\n    name # the name\n  }\n}")
+	b := NormalizeQuery("query GetUser($id:ID!){user(id:$id){id name}}")
+	if a != b {
+		t.Error("The normalized queries should be equal", a, b)
+	}
+	if NormalizeQuery("{ user { id } ") != "{ user { id }" {
+		t.Error("The invalid queries should be stripped from the extra whitespace", NormalizeQuery("{ user { id } "))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schemas := NewSchemas("../config")
+	violations, err := schemas.Validate(schemaFile, Request{Query: "query GetUser($id: ID!) { user(id: $id) { id name } }", Variables: map[string]interface{}{"id": "1"}})
+	if err != nil || len(violations) != 0 {
+		t.Error("The valid request should not have violations", violations, err)
+	}
+	violations, _ = schemas.Validate(schemaFile, Request{Query: "{ user(id: 1) { nickname } }"})
+	if len(violations) != 1 || !strings.Contains(violations[0], "nickname") {
+		t.Error("The unknown fields should be reported", violations)
+	}
+	violations, _ = schemas.Validate(schemaFile, Request{Query: "query GetUser($id: ID!) { user(id: $id) { id } }"})
+	if len(violations) != 1 || !strings.Contains(violations[0], "id") {
+		t.Error("The missing variables should be reported", violations)
+	}
+	if _, err := schemas.Validate("graphql/missing.graphql", Request{Query: "{ users { id } }"}); err == nil {
+		t.Error("Missing schema files should fail")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	schemas := NewSchemas("../config")
+	query := "query { users { id ...UserFields friends { name } } } fragment UserFields on User { name role __typename }"
+	body, err := schemas.Generate(schemaFile, Request{Query: query})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"data": {"users": [{"id": "{{fake.UUID}}", "name": "{{fake.FullName}}", "role": "ADMIN", "__typename": "User", "friends": [{"name": "{{fake.FullName}}"}]}]}}`
+	if body != expected {
+		t.Error("Unexpected generated response", body)
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		t.Error("The generated response should be valid JSON", err)
+	}
+	if _, err := schemas.Generate(schemaFile, Request{Query: "{ users { nickname } }"}); err != ErrInvalidQuery {
+		t.Error("Invalid queries should not be generated", err)
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	schemas := NewSchemas("../config")
+	request := Request{Query: "{ user(id: 1) { id name role friends { name } } }"}
+	violations, err := schemas.ValidateResponse(schemaFile, request, `{"data": {"user": {"id": 1, "name": "John", "role": "ADMIN", "friends": []}}}`)
+	if err != nil || len(violations) != 0 {
+		t.Error("The valid response should not have violations", violations, err)
+	}
+	violations, _ = schemas.ValidateResponse(schemaFile, request, `{"data": {"user": {"id": "1", "name": null, "role": "OWNER", "friends": [{}]}}}`)
+	expected := []string{"data.user.name: should not be null", "data.user.role: should be a Role value", "data.user.friends[0].name: is missing"}
+	if len(violations) != len(expected) {
+		t.Fatal("Unexpected violations", violations)
+	}
+	for i := range expected {
+		if violations[i] != expected[i] {
+			t.Error("Unexpected violation", violations[i])
+		}
+	}
+	if violations, _ := schemas.ValidateResponse(schemaFile, request, `{"errors": [{"message": "Not found"}]}`); len(violations) != 0 {
+		t.Error("The error responses should not be validated", violations)
+	}
+}
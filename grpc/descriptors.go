@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+//ErrNoServices when there are no services in the proto files and descriptor sets
+var ErrNoServices = errors.New("No gRPC services found")
+
+//descriptorSetExtensions are the extensions of the binary FileDescriptorSet files e.g. the ones generated with protoc --descriptor_set_out
+var descriptorSetExtensions = []string{".pb", ".protoset", ".desc"}
+
+//Descriptors contains the gRPC methods defined in the proto files and the descriptor sets.
+type Descriptors struct {
+	sync.RWMutex
+	path    string
+	methods map[string]protoreflect.MethodDescriptor
+}
+
+//LoadDescriptors compiles the .proto files and reads the descriptor sets found in the path.
+//The proto files imports are resolved relative to the path, the well-known types are included.
+func LoadDescriptors(path string) (*Descriptors, error) {
+	protoFiles := []string{}
+	descriptorSets := []string{}
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		ext := filepath.Ext(file)
+		if ext == ".proto" {
+			rel, err := filepath.Rel(path, file)
+			if err != nil {
+				return err
+			}
+			protoFiles = append(protoFiles, filepath.ToSlash(rel))
+		} else if includes(descriptorSetExtensions, ext) {
+			descriptorSets = append(descriptorSets, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Descriptors{path: path, methods: make(map[string]protoreflect.MethodDescriptor)}
+	if len(protoFiles) > 0 {
+		compiler := protocompile.Compiler{
+			Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{path}}),
+		}
+		files, err := compiler.Compile(context.Background(), protoFiles...)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			logging.Printf("Loading proto file: %s\n", file.Path())
+			d.addFile(file)
+		}
+	}
+	for _, file := range descriptorSets {
+		logging.Printf("Loading descriptor set: %s\n", file)
+		if err := d.addDescriptorSet(file); err != nil {
+			return nil, err
+		}
+	}
+	if len(d.methods) == 0 {
+		return nil, ErrNoServices
+	}
+	return d, nil
+}
+
+//Reload loads the proto files and the descriptor sets of the path again, the current methods are kept when they can't be loaded
+func (d *Descriptors) Reload() error {
+	loaded, err := LoadDescriptors(d.path)
+	if err != nil {
+		return err
+	}
+	d.Lock()
+	defer d.Unlock()
+	d.methods = loaded.methods
+	return nil
+}
+
+//FindMethod returns the method with the given full name e.g. "/helloworld.Greeter/SayHello"
+func (d *Descriptors) FindMethod(fullMethod string) (protoreflect.MethodDescriptor, bool) {
+	d.RLock()
+	defer d.RUnlock()
+	method, found := d.methods["/"+strings.TrimPrefix(fullMethod, "/")]
+	return method, found
+}
+
+//Methods returns the full names of all the methods
+func (d *Descriptors) Methods() []string {
+	d.RLock()
+	defer d.RUnlock()
+	methods := make([]string, 0, len(d.methods))
+	for name := range d.methods {
+		methods = append(methods, name)
+	}
+	return methods
+}
+
+func (d *Descriptors) addDescriptorSet(filename string) error {
+	buf, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(buf, set); err != nil {
+		return err
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return err
+	}
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		d.addFile(file)
+		return true
+	})
+	return nil
+}
+
+func (d *Descriptors) addFile(file protoreflect.FileDescriptor) {
+	services := file.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			method := methods.Get(j)
+			d.methods["/"+string(services.Get(i).FullName())+"/"+string(method.Name())] = method
+		}
+	}
+}
+
+func includes(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/journal"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/notify"
+	"github.com/vtrifonov/http-api-mock/route"
+	"github.com/vtrifonov/http-api-mock/utils"
+	"github.com/vtrifonov/http-api-mock/vars"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+//ErrInvalidStatusCode when the mock response has an unknown gRPC status code
+var ErrInvalidStatusCode = errors.New("Invalid gRPC status code")
+
+//nearMissesLimit is the number of the closest mock definitions logged for a not matched call
+const nearMissesLimit = 3
+
+//Dispatcher is the mock gRPC server, it serves the unary calls of the methods in the descriptors.
+//The calls are routed as requests with the GRPC method, the full method name as path and the request message as JSON body.
+type Dispatcher struct {
+	IP            string
+	Port          int
+	Descriptors   *Descriptors
+	Router        route.Router
+	VarsProcessor vars.VarsProcessor
+	Notifier      notify.Notifier
+	Mlog          chan definition.Match
+	Journal       *journal.Journal
+}
+
+//Start initialize the gRPC mock server
+func (di Dispatcher) Start() {
+	addr := fmt.Sprintf("%s:%d", di.IP, di.Port)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		logging.Fatalf("Listen: %s", err.Error())
+	}
+
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(di.handleCall))
+	if err := srv.Serve(listener); err != nil {
+		logging.Fatalf("Serve: %s", err.Error())
+	}
+}
+
+func (di *Dispatcher) handleCall(srv interface{}, stream grpc.ServerStream) error {
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	logging.Printf("New gRPC call: %s\n", fullMethod)
+
+	method, found := di.Descriptors.FindMethod(fullMethod)
+	if !found {
+		return status.Errorf(codes.Unimplemented, "Unknown method %s", fullMethod)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return status.Errorf(codes.Unimplemented, "Only the unary calls are supported, %s is streaming", fullMethod)
+	}
+
+	in := dynamicpb.NewMessage(method.Input())
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	body, err := protojson.Marshal(in)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	mRequest := definition.Request{Method: definition.GRPCMethod, Path: fullMethod, Body: string(body)}
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		mRequest.Headers = definition.Values(md.Copy())
+		if authority := md.Get(":authority"); len(authority) > 0 {
+			mRequest.Host = authority[0]
+		}
+	}
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		mRequest.RemoteAddr = p.Addr.String()
+	}
+
+	result := definition.Result{}
+	mock, errs := di.Router.Route(&mRequest)
+	if errs == nil {
+		result.Found = true
+	} else {
+		result.Errors = errs
+		result.NearMisses = di.Router.NearMisses(&mRequest, nearMissesLimit)
+		for _, nearMiss := range result.NearMisses {
+			logging.Printf("Near miss: %s Score: %.2f\n", nearMiss.MockName, nearMiss.Score)
+		}
+	}
+	logging.Printf("Mock match found: %s. Name : %s\n", strconv.FormatBool(result.Found), mock.Name)
+
+	var callErr error
+	if result.Found {
+		di.VarsProcessor.Eval(&mRequest, mock)
+
+		if !reflect.DeepEqual(definition.Notify{}, mock.Notify) && di.Notifier != nil {
+			go di.Notifier.Notify(mock)
+		}
+		if delay := utils.GetResponseDelay(mock.Control); delay > 0 {
+			logging.Printf("Adding a delay of %s\n", delay)
+			time.Sleep(delay)
+		}
+		callErr = di.writeResponse(stream, method.Output(), &mock.Response)
+	} else {
+		mock.Response = definition.Response{GRPC: &definition.GRPCStatus{Code: codes.Unimplemented.String(), Message: "No mock definition matches the call"}}
+		callErr = status.Error(codes.Unimplemented, mock.Response.GRPC.Message)
+	}
+
+	m := definition.Match{MockName: mock.Name, Request: mRequest, Response: mock.Response, Result: result, Persist: mock.Persist}
+	if di.Journal != nil {
+		di.Journal.Record(m)
+	}
+	if di.Mlog != nil {
+		go di.recordMatchData(m)
+	}
+	return callErr
+}
+
+func (di Dispatcher) recordMatchData(msg definition.Match) {
+	di.Mlog <- msg
+}
+
+//writeResponse sends the headers, trailers and either the response message or the error status of the mock
+func (di *Dispatcher) writeResponse(stream grpc.ServerStream, output protoreflect.MessageDescriptor, response *definition.Response) error {
+	if len(response.Headers) > 0 {
+		if err := stream.SetHeader(toMetadata(response.Headers)); err != nil {
+			return err
+		}
+	}
+
+	code := codes.OK
+	message := ""
+	if response.GRPC != nil {
+		stream.SetTrailer(toMetadata(response.GRPC.Trailers))
+		var err error
+		if code, err = ParseCode(response.GRPC.Code); err != nil {
+			logging.Printf("Error writing the gRPC response: %s %s\n", err.Error(), response.GRPC.Code)
+			return status.Error(codes.Internal, err.Error())
+		}
+		message = response.GRPC.Message
+	}
+	if code != codes.OK {
+		return status.Error(code, message)
+	}
+
+	out := dynamicpb.NewMessage(output)
+	if strings.TrimSpace(response.Body) != "" {
+		if err := protojson.Unmarshal([]byte(response.Body), out); err != nil {
+			logging.Printf("Error writing the gRPC response: %s\n", err.Error())
+			return status.Errorf(codes.Internal, "The mock response is not a valid %s message: %s", output.FullName(), err.Error())
+		}
+	}
+	return stream.SendMsg(out)
+}
+
+//ParseCode returns the status code with the given name like "NOT_FOUND" or number, the empty code is OK
+func ParseCode(value string) (codes.Code, error) {
+	if value == "" {
+		return codes.OK, nil
+	}
+	if number, err := strconv.ParseUint(value, 10, 32); err == nil {
+		if number > uint64(codes.Unauthenticated) {
+			return codes.OK, ErrInvalidStatusCode
+		}
+		return codes.Code(number), nil
+	}
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(value)))); err == nil {
+		return code, nil
+	}
+	// the Go names like "NotFound" are accepted as well
+	for code = codes.OK; code <= codes.Unauthenticated; code++ {
+		if strings.EqualFold(code.String(), value) {
+			return code, nil
+		}
+	}
+	return codes.OK, ErrInvalidStatusCode
+}
+
+func toMetadata(values definition.Values) metadata.MD {
+	md := metadata.MD{}
+	for key, items := range values {
+		md.Append(key, items...)
+	}
+	return md
+}
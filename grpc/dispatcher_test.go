@@ -0,0 +1,186 @@
+package grpc
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/match"
+	"github.com/vtrifonov/http-api-mock/persist"
+	"github.com/vtrifonov/http-api-mock/route"
+	"github.com/vtrifonov/http-api-mock/vars"
+	"github.com/vtrifonov/http-api-mock/vars/fakedata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const sayHello = "/helloworld.Greeter/SayHello"
+
+func startTestServer(t *testing.T, mocks []definition.Mock) *grpc.ClientConn {
+	descriptors, err := LoadDescriptors("../config/grpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	di := Dispatcher{
+		Descriptors: descriptors,
+		Router:      route.NewRouter(mocks, match.MockMatch{}, nil),
+		VarsProcessor: vars.VarsProcessor{
+			FillerFactory:  vars.MockFillerFactory{},
+			FakeAdapter:    fakedata.FakeAdapter{},
+			PersistEngines: persist.GetNewPersistEngineBag(persist.NewFilePersister(t.TempDir())),
+		},
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(di.handleCall))
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func newMessages(t *testing.T, name string) (*dynamicpb.Message, *dynamicpb.Message) {
+	descriptors, err := LoadDescriptors("../config/grpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	method, _ := descriptors.FindMethod(sayHello)
+	in := dynamicpb.NewMessage(method.Input())
+	in.Set(method.Input().Fields().ByName("name"), protoreflect.ValueOfString(name))
+	return in, dynamicpb.NewMessage(method.Output())
+}
+
+func TestUnaryCall(t *testing.T) {
+	mocks := []definition.Mock{{
+		Name:    "say-hello",
+		Request: definition.Request{Method: definition.GRPCMethod, Path: sayHello, BodyJSON: []byte(`{"name": "John"}`)},
+		Response: definition.Response{
+			HttpHeaders: definition.HttpHeaders{Headers: definition.Values{"x-mock": []string{"greeter"}}},
+			Body:        `{"message": "Hello {{request.body.name}}", "length": 5}`,
+		},
+	}}
+	conn := startTestServer(t, mocks)
+
+	in, out := newMessages(t, "John")
+	var header metadata.MD
+	if err := conn.Invoke(context.Background(), sayHello, in, out, grpc.Header(&header)); err != nil {
+		t.Fatal(err)
+	}
+	fields := out.Descriptor().Fields()
+	if message := out.Get(fields.ByName("message")).String(); message != "Hello John" {
+		t.Error("The response message should be built from the mock body", message)
+	}
+	if length := out.Get(fields.ByName("length")).Int(); length != 5 {
+		t.Error("Unexpected response length", length)
+	}
+	if values := header.Get("x-mock"); len(values) != 1 || values[0] != "greeter" {
+		t.Error("The mock headers should be sent as header metadata", header)
+	}
+
+	in, out = newMessages(t, "Jane")
+	err := conn.Invoke(context.Background(), sayHello, in, out)
+	if status.Code(err) != codes.Unimplemented {
+		t.Error("The calls without matching mock should be unimplemented", err)
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	mocks := []definition.Mock{{
+		Name:    "say-hello-error",
+		Request: definition.Request{Method: definition.GRPCMethod, Path: sayHello},
+		Response: definition.Response{GRPC: &definition.GRPCStatus{
+			Code:     "NOT_FOUND",
+			Message:  "{{request.body.name}} not found",
+			Trailers: definition.Values{"retry-after": []string{"5"}},
+		}},
+	}}
+	conn := startTestServer(t, mocks)
+
+	in, out := newMessages(t, "John")
+	var trailer metadata.MD
+	err := conn.Invoke(context.Background(), sayHello, in, out, grpc.Trailer(&trailer))
+	if s := status.Convert(err); s.Code() != codes.NotFound || s.Message() != "John not found" {
+		t.Error("The mock status should be returned", err)
+	}
+	if values := trailer.Get("retry-after"); len(values) != 1 || values[0] != "5" {
+		t.Error("The mock trailers should be sent", trailer)
+	}
+}
+
+func TestParseCode(t *testing.T) {
+	cases := map[string]codes.Code{"": codes.OK, "5": codes.NotFound, "NOT_FOUND": codes.NotFound, "not_found": codes.NotFound, "NotFound": codes.NotFound, "CANCELLED": codes.Canceled}
+	for value, expected := range cases {
+		if code, err := ParseCode(value); err != nil || code != expected {
+			t.Error("Unexpected code", value, code, err)
+		}
+	}
+	for _, value := range []string{"17", "MISSING"} {
+		if _, err := ParseCode(value); err != ErrInvalidStatusCode {
+			t.Error("Invalid codes should not be parsed", value)
+		}
+	}
+}
+
+func TestLoadDescriptors(t *testing.T) {
+	descriptors, err := LoadDescriptors("../config/grpc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := descriptors.FindMethod("helloworld.Greeter/SayHello"); !found {
+		t.Error("The methods should be found with or without the leading slash")
+	}
+	if len(descriptors.Methods()) != 2 {
+		t.Error("All the service methods should be loaded", descriptors.Methods())
+	}
+	if _, err := LoadDescriptors("../config/har"); err != ErrNoServices {
+		t.Error("Loading a folder without services should fail", err)
+	}
+}
+
+func TestDescriptors_Reload(t *testing.T) {
+	dir := t.TempDir()
+	writeProto := func(content string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, "service.proto"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeProto(`syntax = "proto3"; package test; message Empty {} service First { rpc Call (Empty) returns (Empty); }`)
+	descriptors, err := LoadDescriptors(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeProto(`syntax = "proto3"; package test; message Empty {} service Second { rpc Call (Empty) returns (Empty); }`)
+	if err := descriptors.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := descriptors.FindMethod("/test.Second/Call"); !found {
+		t.Error("The changed services should be loaded")
+	}
+	if _, found := descriptors.FindMethod("/test.First/Call"); found {
+		t.Error("The removed services should not be found")
+	}
+
+	writeProto(`syntax = "proto3"; service Broken {`)
+	if err := descriptors.Reload(); err == nil {
+		t.Error("The invalid proto files should fail the reload")
+	}
+	if _, found := descriptors.FindMethod("/test.Second/Call"); !found {
+		t.Error("The previous services should be kept when the reload fails")
+	}
+}
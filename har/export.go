@@ -0,0 +1,98 @@
+package har
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/journal"
+)
+
+//Version is the HAR specification version of the exported documents
+const Version = "1.2"
+
+//Export builds a HAR document from the requests in the journal, the baseURL is the mock server address.
+func Export(entries []journal.Entry, baseURL string) Document {
+	log := Log{Version: Version, Creator: Creator{Name: "HTTP API Mock", Version: "1.0.0"}, Entries: []Entry{}}
+	for _, entry := range entries {
+		log.Entries = append(log.Entries, Entry{
+			StartedDateTime: entry.Time.Format(time.RFC3339Nano),
+			Request:         exportRequest(entry.Request, baseURL),
+			Response:        exportResponse(entry.Response),
+		})
+	}
+	return Document{Log: log}
+}
+
+func exportRequest(req definition.Request, baseURL string) Request {
+	res := Request{Method: req.Method, HTTPVersion: "HTTP/1.1", HeadersSize: -1, BodySize: len(req.Body)}
+
+	query := url.Values(req.QueryStringParameters)
+	res.URL = strings.TrimRight(baseURL, "/") + req.Path
+	if len(query) > 0 {
+		res.URL += "?" + query.Encode()
+	}
+	res.QueryString = nameValues(req.QueryStringParameters)
+	// the matcher lowercases the request header names
+	res.Headers = []NameValue{}
+	for _, header := range nameValues(req.Headers) {
+		res.Headers = append(res.Headers, NameValue{Name: http.CanonicalHeaderKey(header.Name), Value: header.Value})
+	}
+
+	res.Cookies = []Cookie{}
+	for _, name := range sortedKeys(req.Cookies) {
+		res.Cookies = append(res.Cookies, Cookie{Name: name, Value: req.Cookies[name]})
+	}
+
+	if req.Body != "" {
+		res.PostData = &PostData{MimeType: firstValue(req.Headers, "Content-Type"), Text: req.Body}
+	}
+	return res
+}
+
+func exportResponse(resp definition.Response) Response {
+	res := Response{Status: resp.StatusCode, StatusText: http.StatusText(resp.StatusCode), HTTPVersion: "HTTP/1.1", HeadersSize: -1, BodySize: len(resp.Body)}
+	res.Headers = nameValues(resp.Headers)
+	res.Cookies = []Cookie{}
+	for _, name := range sortedKeys(resp.Cookies) {
+		res.Cookies = append(res.Cookies, Cookie{Name: name, Value: resp.Cookies[name]})
+	}
+	res.Content = Content{Size: len(resp.Body), MimeType: firstValue(resp.Headers, "Content-Type"), Text: resp.Body}
+	return res
+}
+
+func nameValues(values definition.Values) []NameValue {
+	result := []NameValue{}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range values[name] {
+			result = append(result, NameValue{Name: name, Value: value})
+		}
+	}
+	return result
+}
+
+func firstValue(values definition.Values, name string) string {
+	for key, items := range values {
+		if strings.EqualFold(key, name) && len(items) > 0 {
+			return items[0]
+		}
+	}
+	return ""
+}
+
+func sortedKeys(cookies definition.Cookies) []string {
+	names := make([]string, 0, len(cookies))
+	for name := range cookies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,138 @@
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//IgnoredRequestHeaders are not matched, as they depend on the client or the session which captured the traffic.
+//The names ending with * ignore all the headers starting with them.
+var IgnoredRequestHeaders = []string{
+	"Accept", "Accept-Encoding", "Accept-Language", "Cache-Control", "Connection", "Content-Length",
+	"Cookie", "Host", "Origin", "Pragma", "Referer", "User-Agent",
+	"Sec-Fetch-*", "Sec-Ch-Ua*", "Upgrade-Insecure-Requests", "X-Requested-With", "Priority",
+	"If-None-Match", "If-Modified-Since", "DNT",
+}
+
+//Reader generates mock definitions from the entries of HAR files.
+type Reader struct {
+}
+
+//CanRead return true if is a har file
+func (r Reader) CanRead(filename string, content []byte) bool {
+	return filepath.Ext(filename) == ".har"
+}
+
+//ReadMultiple returns a mock definition for every distinct request in the file.
+//The responses of the repeated requests are served one after another.
+func (r Reader) ReadMultiple(filename string, content []byte) ([]definition.Mock, error) {
+	logging.Printf("Loading HAR config: %s\n", filename)
+	doc := Document{}
+	if err := json.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	return BuildMocks(doc.Log), nil
+}
+
+//BuildMocks turns the log entries into mock definitions.
+func BuildMocks(log Log) []definition.Mock {
+	mocks := []definition.Mock{}
+	indexes := map[string]int{}
+	for _, entry := range log.Entries {
+		request, err := buildRequest(entry.Request)
+		if err != nil {
+			logging.Printf("Invalid HAR entry %s %s: %s\n", entry.Request.Method, entry.Request.URL, err.Error())
+			continue
+		}
+		response := buildResponse(entry.Response)
+
+		key := fmt.Sprintf("%s %s?%v\n%s", request.Method, request.Path, request.QueryStringParameters, request.Body)
+		if i, found := indexes[key]; found {
+			mocks[i].Responses = append(mocks[i].Responses, response)
+			continue
+		}
+		indexes[key] = len(mocks)
+
+		mock := definition.Mock{Request: request, Response: response}
+		mock.Name = fmt.Sprintf("%d-%s-%s", len(mocks)+1, strings.ToLower(request.Method), utils.CleanName(request.Path))
+		mocks = append(mocks, mock)
+	}
+	for i := range mocks {
+		// a single response doesn't need a sequence
+		if len(mocks[i].Responses) > 0 {
+			mocks[i].Responses = append([]definition.Response{mocks[i].Response}, mocks[i].Responses...)
+		}
+	}
+	return mocks
+}
+
+func buildRequest(entry Request) (definition.Request, error) {
+	res := definition.Request{}
+	u, err := url.Parse(entry.URL)
+	if err != nil {
+		return res, err
+	}
+	res.Method = strings.ToUpper(entry.Method)
+	res.Path = u.Path
+	if res.Path == "" {
+		res.Path = "/"
+	}
+
+	query := u.Query()
+	if len(entry.QueryString) > 0 {
+		query = url.Values{}
+		for _, param := range entry.QueryString {
+			query.Add(param.Name, param.Value)
+		}
+	}
+	if len(query) > 0 {
+		res.QueryStringParameters = definition.Values(query)
+	}
+
+	for _, header := range entry.Headers {
+		if strings.HasPrefix(header.Name, ":") || utils.IsHeaderIgnored(IgnoredRequestHeaders, header.Name) {
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = make(definition.Values)
+		}
+		name := http.CanonicalHeaderKey(header.Name)
+		res.Headers[name] = append(res.Headers[name], header.Value)
+	}
+
+	if entry.PostData != nil {
+		res.Body = entry.PostData.Text
+	}
+	return res, nil
+}
+
+func buildResponse(entry Response) definition.Response {
+	res := definition.Response{StatusCode: entry.Status}
+	for _, header := range entry.Headers {
+		if utils.IsHeaderIgnored(utils.IgnoredResponseHeaders, header.Name) {
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = make(definition.Values)
+		}
+		name := http.CanonicalHeaderKey(header.Name)
+		res.Headers[name] = append(res.Headers[name], header.Value)
+	}
+
+	res.Body = entry.Content.Text
+	if entry.Content.Encoding == "base64" {
+		if body, err := base64.StdEncoding.DecodeString(entry.Content.Text); err == nil {
+			res.Body = string(body)
+		}
+	}
+	return res
+}
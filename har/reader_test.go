@@ -0,0 +1,107 @@
+package har
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/journal"
+)
+
+const harDocument = `{
+	"log": {
+		"version": "1.2",
+		"entries": [
+			{
+				"request": {
+					"method": "POST",
+					"url": "https://api.example.com/users?notify=true",
+					"headers": [
+						{"name": ":authority", "value": "api.example.com"},
+						{"name": "user-agent", "value": "Mozilla/5.0"},
+						{"name": "sec-fetch-mode", "value": "cors"},
+						{"name": "sec-ch-ua-platform", "value": "\"Linux\""},
+						{"name": "if-none-match", "value": "W/\"1\""},
+						{"name": "x-requested-with", "value": "XMLHttpRequest"},
+						{"name": "authorization", "value": "Bearer token"}
+					],
+					"queryString": [{"name": "notify", "value": "true"}],
+					"postData": {"mimeType": "application/json", "text": "{\"name\":\"John\"}"}
+				},
+				"response": {
+					"status": 201,
+					"headers": [
+						{"name": "content-type", "value": "application/json"},
+						{"name": "content-length", "value": "9"}
+					],
+					"content": {"mimeType": "application/json", "text": "eyJpZCI6MX0=", "encoding": "base64"}
+				}
+			},
+			{
+				"request": {"method": "GET", "url": "https://api.example.com/status"},
+				"response": {"status": 503, "content": {"text": "busy"}}
+			},
+			{
+				"request": {"method": "GET", "url": "https://api.example.com/status"},
+				"response": {"status": 200, "content": {"text": "ok"}}
+			}
+		]
+	}
+}`
+
+func TestBuildMocks(t *testing.T) {
+	doc := Document{}
+	if err := json.Unmarshal([]byte(harDocument), &doc); err != nil {
+		t.Fatal(err)
+	}
+	mocks := BuildMocks(doc.Log)
+	if len(mocks) != 2 {
+		t.Fatal("A mock should be created for every distinct request", len(mocks))
+	}
+
+	mock := mocks[0]
+	if mock.Name != "1-post-users" || mock.Request.Method != "POST" || mock.Request.Path != "/users" {
+		t.Error("Unexpected request", mock.Name, mock.Request.Method, mock.Request.Path)
+	}
+	if mock.Request.QueryStringParameters["notify"][0] != "true" || mock.Request.Body != `{"name":"John"}` {
+		t.Error("Unexpected query string or body", mock.Request.QueryStringParameters, mock.Request.Body)
+	}
+	if len(mock.Request.Headers) != 1 || mock.Request.Headers["Authorization"][0] != "Bearer token" {
+		t.Error("The client specific headers should be ignored", mock.Request.Headers)
+	}
+	if mock.Response.StatusCode != 201 || mock.Response.Body != `{"id":1}` || len(mock.Response.Headers) != 1 {
+		t.Error("Unexpected response", mock.Response)
+	}
+
+	mock = mocks[1]
+	if len(mock.Responses) != 2 || mock.Responses[0].StatusCode != 503 || mock.Responses[1].StatusCode != 200 {
+		t.Error("The responses of the repeated requests should be served in sequence", mock.Responses)
+	}
+}
+
+func TestExport(t *testing.T) {
+	match := definition.Match{}
+	match.Request = definition.Request{Method: "GET", Path: "/users", QueryStringParameters: definition.Values{"page": []string{"2"}}}
+	match.Request.Headers = definition.Values{"Accept": []string{"application/json"}}
+	match.Response = definition.Response{StatusCode: 200, Body: "[]"}
+	match.Response.Headers = definition.Values{"Content-Type": []string{"application/json"}}
+
+	doc := Export([]journal.Entry{{Time: time.Now(), Match: match}}, "http://localhost:8083/")
+	if len(doc.Log.Entries) != 1 {
+		t.Fatal("Every journal entry should be exported", len(doc.Log.Entries))
+	}
+	entry := doc.Log.Entries[0]
+	if entry.Request.URL != "http://localhost:8083/users?page=2" {
+		t.Error("Unexpected URL", entry.Request.URL)
+	}
+	if entry.Response.StatusText != "OK" || entry.Response.Content.Text != "[]" || entry.Response.Content.MimeType != "application/json" {
+		t.Error("Unexpected response", entry.Response)
+	}
+
+	// the exported traffic can be replayed
+	mocks := BuildMocks(doc.Log)
+	if len(mocks) != 1 || mocks[0].Request.Path != "/users" || mocks[0].Response.Body != "[]" {
+		t.Error("The exported document should be readable", mocks)
+	}
+}
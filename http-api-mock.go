@@ -1,6 +1,7 @@
 package main
 
 import (
+	"crypto/tls"
 	"errors"
 	"flag"
 	"fmt"
@@ -8,13 +9,23 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/vtrifonov/http-api-mock/admin"
+	"github.com/vtrifonov/http-api-mock/certs"
 	"github.com/vtrifonov/http-api-mock/console"
 	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/graphql"
+	"github.com/vtrifonov/http-api-mock/grpc"
+	"github.com/vtrifonov/http-api-mock/har"
+	"github.com/vtrifonov/http-api-mock/journal"
 	"github.com/vtrifonov/http-api-mock/logging"
 	"github.com/vtrifonov/http-api-mock/match"
 	"github.com/vtrifonov/http-api-mock/notify"
+	"github.com/vtrifonov/http-api-mock/openapi"
 	"github.com/vtrifonov/http-api-mock/persist"
+	"github.com/vtrifonov/http-api-mock/postman"
+	"github.com/vtrifonov/http-api-mock/proxy"
 	"github.com/vtrifonov/http-api-mock/route"
+	"github.com/vtrifonov/http-api-mock/scenario"
 	"github.com/vtrifonov/http-api-mock/server"
 	"github.com/vtrifonov/http-api-mock/translate"
 	"github.com/vtrifonov/http-api-mock/utils"
@@ -28,6 +39,9 @@ var ErrNotFoundDefaultPath = errors.New("We can't determinate the current path")
 //ErrNotFoundAnyMock when we don't found any valid mock definition to load
 var ErrNotFoundAnyMock = errors.New("No valid mock definition found")
 
+//ErrMissingCertificate when a HTTPS port is set without certificate
+var ErrMissingCertificate = errors.New("HTTPS requires a certificate file or a self-signed certificate")
+
 func banner() {
 	fmt.Println("HTTP API Mock v 1.0.0")
 	fmt.Println("")
@@ -87,35 +101,107 @@ func loadVarsProcessorEngines(persistPath string) *persist.PersistEngineBag {
 	return persistBag
 }
 
-func getVarsProcessor(persistEngineBag *persist.PersistEngineBag) vars.VarsProcessor {
+func getVarsProcessor(persistEngineBag *persist.PersistEngineBag, fakeSeed string) vars.VarsProcessor {
 
-	return vars.VarsProcessor{FillerFactory: vars.MockFillerFactory{}, FakeAdapter: fakedata.FakeAdapter{}, PersistEngines: persistEngineBag}
+	return vars.VarsProcessor{FillerFactory: vars.MockFillerFactory{}, FakeAdapter: fakedata.FakeAdapter{}, PersistEngines: persistEngineBag, FakeSeed: fakeSeed}
 }
 
-func startServer(ip string, port int, done chan bool, router route.Router, mLog chan definition.Match, varsProcessor vars.VarsProcessor, logs chan string) {
+func startServer(ip string, port int, tlsPort int, tlsConfig *tls.Config, done chan bool, router route.Router, mLog chan definition.Match, varsProcessor vars.VarsProcessor, logs chan string, requestsJournal *journal.Journal, recorder *proxy.Recorder, nearMisses bool, validator *openapi.Validator, validationStatusCode int, graphQLSchemas *graphql.Schemas) {
 	dispatcher := server.Dispatcher{IP: ip,
+		Port:                 port,
+		TLSPort:              tlsPort,
+		TLSConfig:            tlsConfig,
+		Router:               router,
+		Translator:           translate.HTTPTranslator{},
+		VarsProcessor:        varsProcessor,
+		Mlog:                 mLog,
+		Notifier:             notify.NewMockNotifier(),
+		Logs:                 logs,
+		Journal:              requestsJournal,
+		Recorder:             recorder,
+		NearMisses:           nearMisses,
+		Validator:            validator,
+		ValidationStatusCode: validationStatusCode,
+		GraphQL:              graphQLSchemas,
+		ValidateGraphQL:      validator != nil,
+	}
+	dispatcher.Start()
+	done <- true
+}
+func startGRPCServer(ip string, port int, descriptors *grpc.Descriptors, done chan bool, router route.Router, mLog chan definition.Match, varsProcessor vars.VarsProcessor, requestsJournal *journal.Journal) {
+	dispatcher := grpc.Dispatcher{IP: ip,
 		Port:          port,
+		Descriptors:   descriptors,
 		Router:        router,
-		Translator:    translate.HTTPTranslator{},
 		VarsProcessor: varsProcessor,
-		Mlog:          mLog,
 		Notifier:      notify.NewMockNotifier(),
-		Logs:          logs,
+		Mlog:          mLog,
+		Journal:       requestsJournal,
 	}
 	dispatcher.Start()
 	done <- true
 }
-func startConsole(ip string, port int, done chan bool, mLog chan definition.Match, logs chan string) {
-	dispatcher := console.Dispatcher{IP: ip, Port: port, Mlog: mLog, Logs: logs}
+
+func startConsole(ip string, port int, tlsPort int, tlsConfig *tls.Config, done chan bool, mLog chan definition.Match, logs chan string, requestsJournal *journal.Journal) {
+	dispatcher := console.Dispatcher{IP: ip, Port: port, TLSPort: tlsPort, TLSConfig: tlsConfig, Mlog: mLog, Logs: logs, Journal: requestsJournal}
+	dispatcher.Start()
+	done <- true
+}
+
+func startAdmin(ip string, port int, done chan bool, mocks route.MockManager, requestsJournal *journal.Journal, scenarios *scenario.Store) {
+	dispatcher := admin.Dispatcher{IP: ip, Port: port, Mocks: mocks, Journal: requestsJournal, Scenarios: scenarios}
 	dispatcher.Start()
 	done <- true
 }
 
-func getMocks(path string, updateCh chan []definition.Mock) []definition.Mock {
+//getTLSConfig loads the certificate files or generates a self-signed one, it returns nil if neither is configured.
+func getTLSConfig(certFile, keyFile string, selfSigned bool, hosts []string, caFile string) *tls.Config {
+	if certFile != "" {
+		cert, err := certs.LoadCertificate(certFile, keyFile)
+		if err != nil {
+			logging.Fatalf("Error loading the certificate: %s\n", err.Error())
+		}
+		return certs.NewTLSConfig(cert)
+	}
+	if !selfSigned {
+		return nil
+	}
+
+	ca, err := certs.NewAuthority("HTTP API Mock CA")
+	if err != nil {
+		logging.Fatalf("Error generating the certificate authority: %s\n", err.Error())
+	}
+	cert, err := ca.Issue(hosts)
+	if err != nil {
+		logging.Fatalf("Error generating the certificate: %s\n", err.Error())
+	}
+	if err := ca.WriteCertificate(caFile); err != nil {
+		logging.Fatalf("Error exporting the certificate authority: %s\n", err.Error())
+	}
+	logging.Printf("Self-signed certificate authority exported to: %s\n", caFile)
+	return certs.NewTLSConfig(cert)
+}
+
+//forwardUpdates passes the reloaded mock definitions to the router, the gRPC services are reloaded along with them
+func forwardUpdates(fileUpdates chan []definition.Mock, dUpdates chan []definition.Mock, descriptors *grpc.Descriptors) {
+	for mocks := range fileUpdates {
+		if descriptors != nil {
+			if err := descriptors.Reload(); err != nil {
+				logging.Printf("Error reloading the gRPC services, the previous ones are kept: %s\n", err.Error())
+			}
+		}
+		dUpdates <- mocks
+	}
+}
+
+func getMocks(path string, updateCh chan []definition.Mock) ([]definition.Mock, *definition.FileDefinition) {
 	logging.Printf("Reading Mock definition from: %s\n", path)
 
 	definitionReader := definition.NewFileDefinition(path, updateCh)
 
+	definitionReader.AddMultipleConfigReader(openapi.Reader{})
+	definitionReader.AddMultipleConfigReader(har.Reader{})
+	definitionReader.AddMultipleConfigReader(postman.Reader{})
 	definitionReader.AddConfigReader(definition.JSONReader{})
 	definitionReader.AddConfigReader(definition.YAMLReader{})
 
@@ -124,7 +210,7 @@ func getMocks(path string, updateCh chan []definition.Mock) []definition.Mock {
 		logging.Fatalln(ErrNotFoundAnyMock.Error())
 	}
 	definitionReader.WatchDir()
-	return mocks
+	return mocks, definitionReader
 }
 
 func main() {
@@ -136,6 +222,7 @@ func main() {
 	}
 
 	persistPath, _ := filepath.Abs("./data")
+	caPath, _ := filepath.Abs("./ca.pem")
 	//persistPath := "mongodb://localhost/http-api-mock"
 
 	sIP := flag.String("server-ip", outIP, "Mock server IP")
@@ -143,8 +230,33 @@ func main() {
 	cIP := flag.String("console-ip", outIP, "Console Server IP")
 	cPort := flag.Int("console-port", 8082, "Console server Port")
 	console := flag.Bool("console", true, "Console enabled  (true/false)")
+	// the admin API is not authenticated, so it is reachable only locally unless the IP is set
+	aIP := flag.String("admin-ip", "127.0.0.1", "Admin API Server IP, set it to expose the unauthenticated API to other hosts")
+	aPort := flag.Int("admin-port", 8084, "Admin API server Port")
+	adminAPI := flag.Bool("admin", true, "Admin API enabled  (true/false)")
+	record := flag.Bool("record", false, "Record all the proxied requests as mock definitions in the record path (true/false)")
+	cRecordPath := flag.String("record-path", "", "Folder where the recorded mock definitions are written, the config path by default")
+	recordFormat := flag.String("record-format", "json", "Format of the recorded mock definitions (json/yaml)")
+	recordStripHeaders := flag.String("record-strip-headers", "Date,Content-Length,Transfer-Encoding,Connection", "Comma separated response headers which are not recorded")
+	recordDeduplicate := flag.Bool("record-deduplicate", true, "Record identical requests only once (true/false)")
+	nearMisses := flag.Bool("near-misses", false, "Return the closest mock definitions in the body of the not matched requests (true/false)")
+	validateRequests := flag.Bool("validate-requests", true, "Validate the requests of the mocks linked to OpenAPI operations or GraphQL schemas (true/false)")
+	validationStatusCode := flag.Int("validation-status-code", 400, "Status code of the requests not valid against the OpenAPI operation or the GraphQL schema, enabled by validate-requests")
+	journalSize := flag.Int("journal-size", 1000, "Number of requests kept in the request journal (0 disables it)")
+	sTLSPort := flag.Int("server-https-port", 0, "Mock Server HTTPS Port (0 disables it)")
+	cTLSPort := flag.Int("console-https-port", 0, "Console server HTTPS Port (0 disables it)")
+	tlsCertFile := flag.String("tls-cert-file", "", "PEM encoded certificate file used for HTTPS")
+	tlsKeyFile := flag.String("tls-key-file", "", "PEM encoded private key file of the certificate")
+	tlsSelfSigned := flag.Bool("tls-self-signed", false, "Generate a self-signed certificate authority and certificate when no certificate file is set (true/false)")
+	tlsHosts := flag.String("tls-hosts", "localhost,127.0.0.1", "Comma separated host names and IPs of the generated certificate, the server and console IPs are included as well")
+	tlsClientCAFile := flag.String("tls-client-ca-file", "", "PEM encoded certificate authorities used for verifying the client certificates")
+	tlsClientCertRequired := flag.Bool("tls-client-cert-required", false, "Reject the HTTPS requests without client certificate (true/false)")
+	tlsCAFile := flag.String("tls-ca-file", caPath, "Path where the generated certificate authority is exported")
+	gPort := flag.Int("grpc-port", 0, "Mock gRPC Server Port (0 disables it)")
+	gProtoPath := flag.String("grpc-proto-path", "", "Folder with the .proto files and descriptor sets of the mocked gRPC services, the config path by default")
 	cPath := flag.String("config-path", path, "Mocks definition folder")
 	cPersistPath := flag.String("config-persist-path", persistPath, "Path to the folder where requests can be persisted or connection string to mongo database starting with mongodb:// and having database at the end /DatabaseName")
+	fakeSeed := flag.String("fake-seed", "", "Seed making the fake data deterministic, the same request method and path get the same data (empty means random data)")
 
 	flag.Parse()
 	path, _ = filepath.Abs(*cPath)
@@ -159,25 +271,94 @@ func main() {
 	dUpdates := make(chan []definition.Mock)
 	done := make(chan bool)
 
-	mocks := getMocks(path, dUpdates)
+	var sTLSConfig, cTLSConfig *tls.Config
+	if *sTLSPort > 0 || *cTLSPort > 0 {
+		hosts := append(strings.Split(*tlsHosts, ","), *sIP, *cIP)
+		tlsConfig := getTLSConfig(*tlsCertFile, *tlsKeyFile, *tlsSelfSigned, hosts, *tlsCAFile)
+		if tlsConfig == nil {
+			logging.Fatalln(ErrMissingCertificate.Error())
+		}
+		if *sTLSPort > 0 {
+			// only the mock server asks for the client certificates, the console keeps the shared configuration
+			clientAuthConfig, err := certs.ConfigureClientAuth(tlsConfig, *tlsClientCAFile, *tlsClientCertRequired)
+			if err != nil {
+				logging.Fatalf("Error loading the client certificate authorities: %s\n", err.Error())
+			}
+			sTLSConfig = clientAuthConfig
+		}
+		if *cTLSPort > 0 {
+			cTLSConfig = tlsConfig
+		}
+	}
+
+	fileUpdates := make(chan []definition.Mock)
+	mocks, definitionReader := getMocks(path, fileUpdates)
 	router := getRouter(mocks, dUpdates)
 
+	var descriptors *grpc.Descriptors
+	if *gPort > 0 {
+		protoPath := path
+		if *gProtoPath != "" {
+			protoPath, _ = filepath.Abs(*gProtoPath)
+		}
+		var err error
+		if descriptors, err = grpc.LoadDescriptors(protoPath); err != nil {
+			logging.Fatalf("Error loading the gRPC services from %s: %s\n", protoPath, err.Error())
+		}
+	}
+	go forwardUpdates(fileUpdates, dUpdates, descriptors)
+
+	recordPath := path
+	if *cRecordPath != "" {
+		recordPath, _ = filepath.Abs(*cRecordPath)
+	}
+	recorder := proxy.NewRecorder(recordPath, *recordFormat, strings.Split(*recordStripHeaders, ","), *recordDeduplicate)
+	recorder.Enabled = *record
+	recorder.Mocks = router
+	recorder.Watcher = definitionReader
+
 	persistEngineBag := loadVarsProcessorEngines(*cPersistPath)
-	varsProcessor := getVarsProcessor(persistEngineBag)
+	varsProcessor := getVarsProcessor(persistEngineBag, *fakeSeed)
+
+	var requestsJournal *journal.Journal
+	if *journalSize > 0 {
+		requestsJournal = journal.NewJournal(*journalSize)
+	}
+
+	var validator *openapi.Validator
+	if *validateRequests {
+		validator = openapi.NewValidator(path)
+	}
 
-	go startServer(*sIP, *sPort, done, router, mLog, varsProcessor, logs)
+	go startServer(*sIP, *sPort, *sTLSPort, sTLSConfig, done, router, mLog, varsProcessor, logs, requestsJournal, recorder, *nearMisses, validator, *validationStatusCode, graphql.NewSchemas(path))
 
 	utils.SetServerAddress(fmt.Sprintf("http://%s:%d", *sIP, *sPort))
 
 	logging.Printf("HTTP Server running at %s\n", utils.GetServerAddress())
+	if sTLSConfig != nil {
+		logging.Printf("HTTPS Server running at https://%s:%d\n", *sIP, *sTLSPort)
+	}
+
+	if *gPort > 0 {
+		go startGRPCServer(*sIP, *gPort, descriptors, done, router, mLog, varsProcessor, requestsJournal)
+		logging.Printf("gRPC Server running at %s:%d\n", *sIP, *gPort)
+	}
 
 	if *console {
-		go startConsole(*cIP, *cPort, done, mLog, logs)
+		go startConsole(*cIP, *cPort, *cTLSPort, cTLSConfig, done, mLog, logs, requestsJournal)
 		logging.Printf("Console running at http://%s:%d\n", *cIP, *cPort)
+		if cTLSConfig != nil {
+			logging.Printf("Console running at https://%s:%d\n", *cIP, *cTLSPort)
+		}
 
 		logging.SetLogger(logging.ChannelLogger{ChannelLog: logs})
 	}
 
+	if *adminAPI {
+		go startAdmin(*aIP, *aPort, done, router, requestsJournal, router.Scenarios)
+		logging.Printf("Admin API running at http://%s:%d/__admin\n", *aIP, *aPort)
+	}
+
 	<-done
 
 }
@@ -0,0 +1,85 @@
+package journal
+
+import (
+	"strings"
+
+	"github.com/ryanuber/go-glob"
+)
+
+//Filter describes the journal entries to look for. Empty fields match everything.
+type Filter struct {
+	Mock    string            `json:"mock"`    // name of the mock which served the request
+	Method  string            `json:"method"`  // http method
+	Path    string            `json:"path"`    // path glob e.g. /users/*
+	Headers map[string]string `json:"headers"` // header name and value glob
+	Body    string            `json:"body"`    // body glob
+}
+
+//Matches checks whether the entry fulfills all the filter conditions.
+func (f Filter) Matches(entry Entry) bool {
+	req := entry.Request
+	if f.Mock != "" && f.Mock != entry.MockName {
+		return false
+	}
+	if f.Method != "" && !strings.EqualFold(f.Method, req.Method) {
+		return false
+	}
+	if f.Path != "" && !glob.Glob(f.Path, req.Path) {
+		return false
+	}
+	for name, pattern := range f.Headers {
+		if !matchesHeader(req.Headers, name, pattern) {
+			return false
+		}
+	}
+	if f.Body != "" && !glob.Glob(f.Body, req.Body) {
+		return false
+	}
+	return true
+}
+
+func matchesHeader(headers map[string][]string, name string, pattern string) bool {
+	for header, values := range headers {
+		if !strings.EqualFold(header, name) {
+			continue
+		}
+		for _, value := range values {
+			if glob.Glob(pattern, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//Verification describes the expected number of journal entries matching the filter.
+//When no expectation is set at least one matching entry is expected.
+type Verification struct {
+	Filter
+	Count   *int `json:"count"`   // exact number of matching entries, 0 means never
+	AtLeast *int `json:"atLeast"` // minimum number of matching entries
+	AtMost  *int `json:"atMost"`  // maximum number of matching entries
+}
+
+//Check returns whether the given number of matching entries fulfills the verification.
+func (v Verification) Check(count int) bool {
+	if v.Count == nil && v.AtLeast == nil && v.AtMost == nil {
+		return count > 0
+	}
+	if v.Count != nil && count != *v.Count {
+		return false
+	}
+	if v.AtLeast != nil && count < *v.AtLeast {
+		return false
+	}
+	if v.AtMost != nil && count > *v.AtMost {
+		return false
+	}
+	return true
+}
+
+//VerificationResult contains the outcome of a verification.
+type VerificationResult struct {
+	Verified bool `json:"verified"`
+	Count    int  `json:"count"`
+}
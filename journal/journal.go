@@ -0,0 +1,67 @@
+package journal
+
+import (
+	"sync"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+//Entry contains a request received by the mock server and the way it was served.
+type Entry struct {
+	Time time.Time `json:"time"`
+	definition.Match
+}
+
+//NewJournal returns a pointer to a new Journal keeping up to size entries.
+func NewJournal(size int) *Journal {
+	return &Journal{size: size, entries: []Entry{}}
+}
+
+//Journal keeps in memory the latest requests received by the mock server, dropping the oldest ones when it is full.
+type Journal struct {
+	size    int
+	entries []Entry
+	sync.Mutex
+}
+
+//Record adds a new entry to the journal.
+func (j *Journal) Record(match definition.Match) {
+	j.Lock()
+	defer j.Unlock()
+	j.entries = append(j.entries, Entry{Time: time.Now(), Match: match})
+	if len(j.entries) > j.size {
+		j.entries = j.entries[len(j.entries)-j.size:]
+	}
+}
+
+//Find returns all the entries matching the filter from the oldest to the newest.
+func (j *Journal) Find(filter Filter) []Entry {
+	j.Lock()
+	defer j.Unlock()
+	entries := []Entry{}
+	for _, entry := range j.entries {
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+//Count returns the number of entries matching the filter.
+func (j *Journal) Count(filter Filter) int {
+	return len(j.Find(filter))
+}
+
+//Verify checks the number of entries matching the verification filter against the expected count.
+func (j *Journal) Verify(verification Verification) VerificationResult {
+	count := j.Count(verification.Filter)
+	return VerificationResult{Verified: verification.Check(count), Count: count}
+}
+
+//Reset removes all the entries from the journal.
+func (j *Journal) Reset() {
+	j.Lock()
+	j.entries = []Entry{}
+	j.Unlock()
+}
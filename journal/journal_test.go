@@ -0,0 +1,79 @@
+package journal
+
+import (
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+func getMatch(mock string, method string, path string) definition.Match {
+	match := definition.Match{MockName: mock}
+	match.Request.Method = method
+	match.Request.Path = path
+	match.Request.Headers = definition.Values{"X-Request-Id": []string{"abc-123"}}
+	match.Request.Body = "{\"name\": \"John\"}"
+	return match
+}
+
+func TestJournal_DropsOldestEntries(t *testing.T) {
+	journal := NewJournal(2)
+	journal.Record(getMatch("first", "GET", "/users/1"))
+	journal.Record(getMatch("second", "GET", "/users/2"))
+	journal.Record(getMatch("third", "GET", "/users/3"))
+
+	entries := journal.Find(Filter{})
+	if len(entries) != 2 {
+		t.Fatal("The journal should keep only two entries", len(entries))
+	}
+	if entries[0].MockName != "second" || entries[1].MockName != "third" {
+		t.Error("The oldest entry should be dropped", entries)
+	}
+}
+
+func TestJournal_Find(t *testing.T) {
+	journal := NewJournal(10)
+	journal.Record(getMatch("users", "GET", "/users/1"))
+	journal.Record(getMatch("users", "POST", "/users"))
+	journal.Record(getMatch("orders", "GET", "/orders/1"))
+
+	if count := journal.Count(Filter{Method: "get"}); count != 2 {
+		t.Error("Two GET requests expected", count)
+	}
+	if count := journal.Count(Filter{Path: "/users*"}); count != 2 {
+		t.Error("Two requests to users expected", count)
+	}
+	if count := journal.Count(Filter{Mock: "orders"}); count != 1 {
+		t.Error("One request to orders expected", count)
+	}
+	if count := journal.Count(Filter{Headers: map[string]string{"x-request-id": "abc-*"}}); count != 3 {
+		t.Error("Three requests with request id expected", count)
+	}
+	if count := journal.Count(Filter{Body: "*Jane*"}); count != 0 {
+		t.Error("No requests with Jane in the body expected", count)
+	}
+}
+
+func TestJournal_Verify(t *testing.T) {
+	journal := NewJournal(10)
+	journal.Record(getMatch("users", "GET", "/users/1"))
+	journal.Record(getMatch("users", "GET", "/users/2"))
+
+	two, zero := 2, 0
+	if result := journal.Verify(Verification{Filter: Filter{Mock: "users"}}); !result.Verified {
+		t.Error("The mock should be called at least once", result)
+	}
+	if result := journal.Verify(Verification{Filter: Filter{Mock: "users"}, Count: &two}); !result.Verified {
+		t.Error("The mock should be called twice", result)
+	}
+	if result := journal.Verify(Verification{Filter: Filter{Mock: "orders"}, Count: &zero}); !result.Verified {
+		t.Error("The mock should never be called", result)
+	}
+	if result := journal.Verify(Verification{Filter: Filter{Mock: "users"}, AtMost: &zero}); result.Verified || result.Count != 2 {
+		t.Error("The verification should fail", result)
+	}
+
+	journal.Reset()
+	if result := journal.Verify(Verification{Filter: Filter{Mock: "users"}}); result.Verified {
+		t.Error("The journal should be empty after reset", result)
+	}
+}
@@ -0,0 +1,73 @@
+package match
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/graphql"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//hasVariables checks whether the mock defines GraphQL variables, null is the same as missing.
+func hasVariables(mock *definition.GraphQL) bool {
+	variables := bytes.TrimSpace(mock.Variables)
+	return len(variables) > 0 && string(variables) != "null"
+}
+
+//matchVariables checks whether the request variables are semantically equal to the mock ones.
+func matchVariables(request graphql.Request, mock *definition.GraphQL) bool {
+	var expected interface{}
+	if err := json.Unmarshal(mock.Variables, &expected); err != nil {
+		return false
+	}
+	var actual interface{} = map[string]interface{}{}
+	if request.Variables != nil {
+		actual = request.Variables
+	}
+	// the variables are compared after a round trip, so the numbers have the same type
+	data, _ := json.Marshal(actual)
+	json.Unmarshal(data, &actual)
+	return utils.JSONValuesAreEqual(expected, actual, mock.VariablesIgnoreExtraFields)
+}
+
+//checkGraphQL checks every field of the mock GraphQL section against the request operation.
+func (fd *fieldDiffs) checkGraphQL(req *definition.Request, mock *definition.GraphQL) {
+	request, found := graphql.ParseRequest(req)
+	if mock.OperationName != "" {
+		fd.check(found && request.OperationName == mock.OperationName, "graphql.operationName", mock.OperationName, operationValue(request.OperationName, found))
+	}
+	if mock.Query != "" {
+		expected := graphql.NormalizeQuery(mock.Query)
+		actual := missingValue
+		if found {
+			actual = graphql.NormalizeQuery(request.Query)
+		}
+		fd.check(found && expected == actual, "graphql.query", expected, actual)
+	}
+	if hasVariables(mock) {
+		actual := missingValue
+		if found {
+			data, _ := json.Marshal(request.Variables)
+			actual = string(data)
+		}
+		fd.check(found && matchVariables(request, mock), "graphql.variables", compactJSON(mock.Variables), actual)
+	}
+	if mock.OperationName == "" && mock.Query == "" && !hasVariables(mock) {
+		fd.check(found, "graphql", "GraphQL request", operationValue(request.Query, found))
+	}
+}
+
+//matchGraphQL checks the GraphQL request against the mock graphql section.
+func (mm MockMatch) matchGraphQL(req *definition.Request, mock *definition.Request) bool {
+	fd := &fieldDiffs{}
+	fd.checkGraphQL(req, mock.GraphQL)
+	return len(fd.diffs) == 0
+}
+
+func operationValue(value string, found bool) string {
+	if !found {
+		return missingValue
+	}
+	return value
+}
@@ -0,0 +1,55 @@
+package match
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//hasBodyJSON checks whether the mock defines a JSON body, null is the same as missing.
+func hasBodyJSON(mock *definition.Request) bool {
+	body := bytes.TrimSpace(mock.BodyJSON)
+	return len(body) > 0 && string(body) != "null"
+}
+
+//matchBodyJSON checks whether the request body is semantically equal to the mock JSON body.
+func (mm MockMatch) matchBodyJSON(req *definition.Request, mock *definition.Request) bool {
+	var expected, actual interface{}
+	if err := json.Unmarshal(mock.BodyJSON, &expected); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(req.Body), &actual); err != nil {
+		return false
+	}
+	return utils.JSONValuesAreEqual(expected, actual, mock.BodyJSONIgnoreExtraFields)
+}
+
+//matchBodyJSONPath checks the values selected by every mock JSONPath expression in the request body.
+func (mm MockMatch) matchBodyJSONPath(req *definition.Request, mock *definition.Request) bool {
+	for path, matcher := range mock.BodyJSONPath {
+		values, err := utils.GetJSONPathValues(req.Body, path)
+		if err != nil {
+			return false
+		}
+		if !matchJSONValues(matcher, values) {
+			return false
+		}
+	}
+	return true
+}
+
+//matchJSONValues checks whether any of the values selected by a JSONPath expression fulfills the matcher.
+func matchJSONValues(matcher definition.ValueMatcher, values []interface{}) bool {
+	// objects and arrays are compared in their compact form with sorted keys
+	var expected interface{}
+	if err := json.Unmarshal([]byte(matcher.EqualTo), &expected); err == nil && (utils.IsObject(expected) || utils.IsArray(expected)) {
+		matcher.EqualTo = utils.JSONValueToString(expected)
+	}
+	strValues := make([]string, len(values))
+	for i, value := range values {
+		strValues[i] = utils.JSONValueToString(value)
+	}
+	return matchValues(matcher, strValues)
+}
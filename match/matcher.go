@@ -8,3 +8,9 @@ import (
 type Matcher interface {
 	Match(req *definition.Request, mock *definition.Request) (bool, error)
 }
+
+//Differ reports the request fields which don't match some specific mock request definition.
+//It returns the number of checks made and the differences found.
+type Differ interface {
+	Diff(req *definition.Request, mock *definition.Request) (int, []definition.FieldDiff)
+}
@@ -10,12 +10,18 @@ import (
 )
 
 var (
-	ErrMethodNotMatch   = errors.New("Method not match")
-	ErrPathNotMatch     = errors.New("Path not match")
-	ErrQueryStringMatch = errors.New("Query string not match")
-	ErrHeadersNotMatch  = errors.New("Headers not match")
-	ErrCookiesNotMatch  = errors.New("Cookies not match")
-	ErrBodyNotMatch     = errors.New("Body not match")
+	ErrMethodNotMatch       = errors.New("Method not match")
+	ErrPathNotMatch         = errors.New("Path not match")
+	ErrQueryStringMatch     = errors.New("Query string not match")
+	ErrHeadersNotMatch      = errors.New("Headers not match")
+	ErrCookiesNotMatch      = errors.New("Cookies not match")
+	ErrTLSNotMatch          = errors.New("Client certificate not match")
+	ErrBodyNotMatch         = errors.New("Body not match")
+	ErrBodyJSONNotMatch     = errors.New("Body JSON not match")
+	ErrBodyJSONPathNotMatch = errors.New("Body JSONPath not match")
+	ErrGraphQLNotMatch      = errors.New("GraphQL request not match")
+	ErrSOAPActionNotMatch   = errors.New("SOAP action not match")
+	ErrBodyXPathNotMatch    = errors.New("Body XPath not match")
 )
 
 type MockMatch struct {
@@ -109,17 +115,53 @@ func (mm MockMatch) Match(req *definition.Request, mock *definition.Request) (bo
 		return false, ErrQueryStringMatch
 	}
 
+	if !matchValuesMap(req.QueryStringParameters, mock.QueryStringMatchers, true) {
+		return false, ErrQueryStringMatch
+	}
+
 	if !mm.matchKeyAndValue(req.Cookies, mock.Cookies) {
 		return false, ErrCookiesNotMatch
 	}
 
+	if !matchCookiesMap(req.Cookies, mock.CookieMatchers) {
+		return false, ErrCookiesNotMatch
+	}
+
 	if !mm.matchKeyAndValues(req.Headers, mock.Headers, false, false) {
 		return false, ErrHeadersNotMatch
 	}
 
+	if !matchValuesMap(req.Headers, mock.HeaderMatchers, false) {
+		return false, ErrHeadersNotMatch
+	}
+
+	if !mm.matchTLS(req, mock) {
+		return false, ErrTLSNotMatch
+	}
+
 	if len(mock.Body) > 0 && !glob.Glob(mock.Body, req.Body) {
 		return false, ErrBodyNotMatch
 	}
 
+	if hasBodyJSON(mock) && !mm.matchBodyJSON(req, mock) {
+		return false, ErrBodyJSONNotMatch
+	}
+
+	if len(mock.BodyJSONPath) > 0 && !mm.matchBodyJSONPath(req, mock) {
+		return false, ErrBodyJSONPathNotMatch
+	}
+
+	if len(mock.SOAPAction) > 0 && !mm.matchSOAPAction(req, mock) {
+		return false, ErrSOAPActionNotMatch
+	}
+
+	if len(mock.BodyXPath) > 0 && !mm.matchBodyXPath(req, mock) {
+		return false, ErrBodyXPathNotMatch
+	}
+
+	if mock.GraphQL != nil && !mm.matchGraphQL(req, mock) {
+		return false, ErrGraphQLNotMatch
+	}
+
 	return true, nil
 }
@@ -201,3 +201,300 @@ func TestMatchIgnoreUnexpectedHeadersAndQuery(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestMatchBodyJSON(t *testing.T) {
+	hreq := &definition.Request{}
+	hreq.Body = "{\"b\": [1, 2], \"a\": {\"c\": \"d\"}}"
+	mreq := &definition.Request{}
+	mreq.BodyJSON = []byte("{\"a\": {\"c\": \"d\"}, \"b\": [1, 2]}")
+	m := MockMatch{}
+
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error(err)
+	}
+
+	mreq.BodyJSON = []byte("{\"a\": {\"c\": \"d\"}}")
+	if m, _ := m.Match(hreq, mreq); m {
+		t.Error("Not expected match")
+	}
+
+	mreq.BodyJSONIgnoreExtraFields = true
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error(err)
+	}
+
+	mreq.BodyJSON = []byte("null")
+	hreq.Body = "not a JSON"
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error(err)
+	}
+}
+
+func TestMatchBodyJSONPath(t *testing.T) {
+	hreq := &definition.Request{}
+	hreq.Body = "{\"user\": {\"id\": 42, \"name\": \"John\", \"roles\": [\"admin\", \"user\"]}}"
+	mreq := &definition.Request{}
+	mreq.BodyJSONPath = definition.ValueMatchers{
+		"$.user.id":       definition.ValueMatcher{EqualTo: "42"},
+		"$.user.name":     definition.ValueMatcher{Matches: "^J"},
+		"$.user.roles[*]": definition.ValueMatcher{EqualTo: "admin"},
+		"$.user.roles":    definition.ValueMatcher{EqualTo: "[\"admin\", \"user\"]"},
+		"$.user.email":    definition.ValueMatcher{Absent: true},
+		"$..name":         definition.ValueMatcher{},
+	}
+	m := MockMatch{}
+
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error(err)
+	}
+
+	mreq.BodyJSONPath["$.user.email"] = definition.ValueMatcher{}
+	if m, _ := m.Match(hreq, mreq); m {
+		t.Error("Not expected match")
+	}
+}
+
+func TestMatchValueMatchers(t *testing.T) {
+	hreq := &definition.Request{}
+	hreq.Method = "GET"
+	hreq.Path = "/a/b/c"
+	hreq.Headers = definition.Values{"Authorization": []string{"Bearer abc123"}, "X-Trace-Id": []string{"4bf92f35"}}
+	hreq.QueryStringParameters = definition.Values{"ts": []string{"1520000000"}, "page": []string{"2"}}
+	hreq.Cookies = definition.Cookies{"session": "s-42"}
+
+	mreq := &definition.Request{}
+	mreq.Method = "GET"
+	mreq.Path = "/a/b/c"
+	mreq.HeaderMatchers = definition.ValueMatchers{
+		"authorization": {Prefix: "Bearer "},
+		"X-Trace-Id":    {Matches: "^[0-9a-f]+$"},
+		"X-Debug":       {Absent: true},
+	}
+	mreq.QueryStringMatchers = definition.ValueMatchers{
+		"ts":   {Matches: `^\d+$`},
+		"page": {NotEqualTo: "0"},
+	}
+	mreq.CookieMatchers = definition.ValueMatchers{
+		"session": {Contains: "42"},
+	}
+
+	m := MockMatch{}
+
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error(err)
+	}
+
+	hreq.QueryStringParameters["page"] = []string{"0"}
+	if m, err := m.Match(hreq, mreq); m || err != ErrQueryStringMatch {
+		t.Error("Query string should not match a not equal value", err)
+	}
+	hreq.QueryStringParameters["page"] = []string{"2"}
+
+	hreq.Cookies["session"] = "s-43"
+	if m, err := m.Match(hreq, mreq); m || err != ErrCookiesNotMatch {
+		t.Error("Cookie should not match a contains value", err)
+	}
+	hreq.Cookies["session"] = "s-42"
+
+	hreq.Headers["X-Debug"] = []string{"1"}
+	if m, err := m.Match(hreq, mreq); m || err != ErrHeadersNotMatch {
+		t.Error("Header should not match an absent value", err)
+	}
+}
+
+func TestMatchValueMatchersEmptyAndPresent(t *testing.T) {
+	hreq := &definition.Request{}
+	hreq.Method = "GET"
+	hreq.Path = "/a"
+	hreq.QueryStringParameters = definition.Values{"filter": []string{""}, "page": []string{"2"}}
+
+	mreq := &definition.Request{}
+	mreq.Method = "GET"
+	mreq.Path = "/a"
+	mreq.QueryStringMatchers = definition.ValueMatchers{
+		"filter": {Empty: true},
+		"page":   {},
+	}
+
+	m := MockMatch{}
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error(err)
+	}
+
+	hreq.QueryStringParameters["filter"] = []string{"name"}
+	if m, err := m.Match(hreq, mreq); m || err != ErrQueryStringMatch {
+		t.Error("Query string should not match an empty value", err)
+	}
+	hreq.QueryStringParameters["filter"] = []string{""}
+
+	delete(hreq.QueryStringParameters, "page")
+	if m, err := m.Match(hreq, mreq); m || err != ErrQueryStringMatch {
+		t.Error("Query string should not match a missing present value", err)
+	}
+}
+
+func TestDiffReportsAllFields(t *testing.T) {
+	hreq := &definition.Request{}
+	hreq.Method = "POST"
+	hreq.Path = "/a/b/c"
+	hreq.QueryStringParameters = definition.Values{"page": []string{"2"}}
+	hreq.Body = `{"name":"John"}`
+
+	mreq := &definition.Request{}
+	mreq.Method = "GET"
+	mreq.Path = "/a/b/*"
+	mreq.QueryStringParameters = definition.Values{"page": []string{"1"}}
+	mreq.Cookies = definition.Cookies{"session": "1"}
+	mreq.BodyJSONPath = definition.ValueMatchers{"$.name": {Prefix: "J"}}
+
+	checks, diffs := MockMatch{}.Diff(hreq, mreq)
+	if checks != 5 {
+		t.Error("All the mock fields should be checked", checks)
+	}
+	expected := []definition.FieldDiff{
+		{Field: "method", Expected: "GET", Actual: "POST"},
+		{Field: "queryStringParameters.page", Expected: "1", Actual: "2"},
+		{Field: "cookies.session", Expected: "1", Actual: missingValue},
+	}
+	if len(diffs) != len(expected) {
+		t.Fatal("Unexpected diffs", diffs)
+	}
+	for i := range expected {
+		if diffs[i] != expected[i] {
+			t.Error("Unexpected diff", diffs[i], expected[i])
+		}
+	}
+}
+
+func TestMatchClientCertificate(t *testing.T) {
+	hreq := &definition.Request{}
+	hreq.Method = "GET"
+	hreq.Path = "/a/b/c"
+
+	mreq := &definition.Request{}
+	mreq.Method = "GET"
+	mreq.Path = "/a/b/c"
+	mreq.TLS = &definition.ClientCertificate{SANs: []string{"client.partner.com"}, Fingerprint: "AB:12"}
+	mreq.TLSMatchers = definition.ValueMatchers{"subject": {Contains: "O=Partner"}}
+
+	m := MockMatch{}
+
+	if m, err := m.Match(hreq, mreq); m || err != ErrTLSNotMatch {
+		t.Error("Request without client certificate should not match", err)
+	}
+
+	hreq.TLS = &definition.ClientCertificate{Subject: "CN=client,O=Partner", SANs: []string{"client.partner.com", "10.0.0.1"}, Fingerprint: "ab12"}
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error(err)
+	}
+
+	hreq.TLS.Subject = "CN=client,O=Other"
+	if m, err := m.Match(hreq, mreq); m || err != ErrTLSNotMatch {
+		t.Error("Client certificate subject should not match", err)
+	}
+}
+
+func TestMatchGraphQL(t *testing.T) {
+	hreq := &definition.Request{}
+	hreq.Method = "POST"
+	hreq.Path = "/graphql"
+	hreq.Body = `{"query": "query GetUser($id: ID!) {\n  user(id: $id) { id, name } # the user\n}", "variables": {"id": "1", "locale": "en"}}`
+
+	mreq := &definition.Request{}
+	mreq.Method = "POST"
+	mreq.Path = "/graphql"
+	mreq.GraphQL = &definition.GraphQL{
+		OperationName:              "GetUser",
+		Query:                      "query GetUser($id: ID!) { user(id: $id) { id name } }",
+		Variables:                  []byte(`{"id": "1"}`),
+		VariablesIgnoreExtraFields: true,
+	}
+
+	m := MockMatch{}
+
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error("The query should match regardless of the formatting", err)
+	}
+
+	mreq.GraphQL.VariablesIgnoreExtraFields = false
+	if m, err := m.Match(hreq, mreq); m || err != ErrGraphQLNotMatch {
+		t.Error("The extra variables should not match", err)
+	}
+
+	mreq.GraphQL = &definition.GraphQL{OperationName: "ListUsers"}
+	if m, _ := m.Match(hreq, mreq); m {
+		t.Error("Other operations should not match")
+	}
+	checks, diffs := m.Diff(hreq, mreq)
+	if checks != 3 || len(diffs) != 1 || diffs[0].Field != "graphql.operationName" || diffs[0].Actual != "GetUser" {
+		t.Error("The operation name should be reported", checks, diffs)
+	}
+
+	hreq.Method = "GET"
+	hreq.Body = ""
+	hreq.QueryStringParameters = definition.Values{"query": []string{"{ users { id } }"}, "operationName": []string{"ListUsers"}}
+	mreq.Method = "GET"
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error("The GET requests should match", err)
+	}
+}
+
+func TestMatchSOAP(t *testing.T) {
+	hreq := &definition.Request{}
+	hreq.Method = "POST"
+	hreq.Path = "/soap/orders"
+	hreq.Headers = definition.Values{"Soapaction": []string{`"http://example.com/orders/GetOrder"`}}
+	hreq.Body = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:ord="http://example.com/orders">` +
+		`<soap:Body><ord:GetOrder><ord:OrderId>42</ord:OrderId></ord:GetOrder></soap:Body></soap:Envelope>`
+
+	mreq := &definition.Request{}
+	mreq.Method = "POST"
+	mreq.Path = "/soap/orders"
+	mreq.SOAPAction = "*/GetOrder"
+	mreq.XMLNamespaces = map[string]string{"o": "http://example.com/orders"}
+	mreq.BodyXPath = definition.ValueMatchers{
+		"//o:GetOrder/o:OrderId": {Matches: `^\d+$`},
+		"//o:Customer":           {Absent: true},
+	}
+
+	m := MockMatch{}
+
+	if m, err := m.Match(hreq, mreq); !m {
+		t.Error("The SOAP request should match", err)
+	}
+
+	mreq.BodyXPath["//o:GetOrder/o:OrderId"] = definition.ValueMatcher{EqualTo: "7"}
+	if m, err := m.Match(hreq, mreq); m || err != ErrBodyXPathNotMatch {
+		t.Error("Other order ids should not match", err)
+	}
+
+	hreq.Headers = definition.Values{"Content-Type": []string{`application/soap+xml; charset=utf-8; action="http://example.com/orders/CancelOrder"`}}
+	if m, err := m.Match(hreq, mreq); m || err != ErrSOAPActionNotMatch {
+		t.Error("Other SOAP 1.2 actions should not match", err)
+	}
+	_, diffs := m.Diff(hreq, mreq)
+	if len(diffs) != 2 || diffs[0].Field != "soapAction" || diffs[0].Actual != "http://example.com/orders/CancelOrder" || diffs[1].Actual != "42" {
+		t.Error("The SOAP action and XPath differences should be reported", diffs)
+	}
+}
+
+func TestMatchWebSocketMessage(t *testing.T) {
+	m := MockMatch{}
+
+	if !m.MatchMessage("anything", &definition.WebSocketReply{}) {
+		t.Error("The reply without checks should match all the messages")
+	}
+	if !m.MatchMessage("bye now", &definition.WebSocketReply{Body: "bye*"}) || m.MatchMessage("hello", &definition.WebSocketReply{Body: "bye*"}) {
+		t.Error("The messages should be matched by the glob pattern")
+	}
+
+	reply := &definition.WebSocketReply{BodyJSON: []byte(`{"type": "ping"}`), BodyJSONIgnoreExtraFields: true}
+	if !m.MatchMessage(`{"id": 7, "type": "ping"}`, reply) || m.MatchMessage(`{"type": "pong"}`, reply) || m.MatchMessage("ping", reply) {
+		t.Error("The messages should be matched by the JSON body")
+	}
+
+	reply = &definition.WebSocketReply{BodyJSONPath: definition.ValueMatchers{"$.id": {Matches: `^\d+$`}}}
+	if !m.MatchMessage(`{"id": 7}`, reply) || m.MatchMessage(`{"id": "a"}`, reply) {
+		t.Error("The messages should be matched by the JSONPath matchers")
+	}
+}
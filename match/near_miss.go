@@ -0,0 +1,175 @@
+package match
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	urlmatcher "github.com/azer/url-router"
+	"github.com/ryanuber/go-glob"
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//missingValue is reported as actual value of the request fields which are not present
+const missingValue = "<missing>"
+
+//fieldDiffs collects the results of the checks made by Diff
+type fieldDiffs struct {
+	checks int
+	diffs  []definition.FieldDiff
+}
+
+func (fd *fieldDiffs) check(ok bool, field, expected, actual string) {
+	fd.checks++
+	if !ok {
+		fd.diffs = append(fd.diffs, definition.FieldDiff{Field: field, Expected: expected, Actual: actual})
+	}
+}
+
+//Diff checks every field of the mock request definition, unlike Match it doesn't stop on the first one not matching.
+func (mm MockMatch) Diff(req *definition.Request, mock *definition.Request) (int, []definition.FieldDiff) {
+	fd := &fieldDiffs{}
+
+	pathMatch := glob.Glob(mock.Path, req.Path) || urlmatcher.New(mock.Path).Match(req.Path) != nil
+	fd.check(pathMatch, "path", mock.Path, req.Path)
+	fd.check(mockIncludesMethod(mock, req.Method), "method", mock.Method, req.Method)
+
+	for _, key := range sortedValuesKeys(mock.QueryStringParameters) {
+		mval := mock.QueryStringParameters[key]
+		ok := mm.matchKeyAndValues(copyValues(req.QueryStringParameters), definition.Values{key: mval}, true, true)
+		fd.check(ok, "queryStringParameters."+key, strings.Join(mval, ", "), joinValues(getValues(req.QueryStringParameters, key, true)))
+	}
+	for _, key := range sortedMatchersKeys(mock.QueryStringMatchers) {
+		matcher := mock.QueryStringMatchers[key]
+		ok := matchValuesMap(req.QueryStringParameters, definition.ValueMatchers{key: matcher}, true)
+		fd.check(ok, "queryStringMatchers."+key, matcher.String(), joinValues(getValues(req.QueryStringParameters, key, true)))
+	}
+
+	for _, key := range sortedCookiesKeys(mock.Cookies) {
+		mval := mock.Cookies[key]
+		rval, present := req.Cookies[key]
+		fd.check(present && rval == mval, "cookies."+key, mval, cookieValue(rval, present))
+	}
+	for _, key := range sortedMatchersKeys(mock.CookieMatchers) {
+		matcher := mock.CookieMatchers[key]
+		rval, present := req.Cookies[key]
+		fd.check(matchValue(matcher, rval, present), "cookieMatchers."+key, matcher.String(), cookieValue(rval, present))
+	}
+
+	for _, key := range sortedValuesKeys(mock.Headers) {
+		mval := mock.Headers[key]
+		ok := mm.matchKeyAndValues(copyValues(req.Headers), definition.Values{key: mval}, false, false)
+		fd.check(ok, "headers."+key, strings.Join(mval, ", "), joinValues(getValues(req.Headers, key, false)))
+	}
+	for _, key := range sortedMatchersKeys(mock.HeaderMatchers) {
+		matcher := mock.HeaderMatchers[key]
+		ok := matchValuesMap(req.Headers, definition.ValueMatchers{key: matcher}, false)
+		fd.check(ok, "headerMatchers."+key, matcher.String(), joinValues(getValues(req.Headers, key, false)))
+	}
+
+	if mock.TLS != nil {
+		fd.checkTLS(req.TLS, mock.TLS)
+	}
+	tlsValues := clientCertificateValues(req.TLS)
+	for _, key := range sortedMatchersKeys(mock.TLSMatchers) {
+		matcher := mock.TLSMatchers[key]
+		ok := matchValuesMap(tlsValues, definition.ValueMatchers{key: matcher}, true)
+		fd.check(ok, "tlsMatchers."+key, matcher.String(), joinValues(tlsValues[key]))
+	}
+
+	if len(mock.Body) > 0 {
+		fd.check(glob.Glob(mock.Body, req.Body), "body", mock.Body, req.Body)
+	}
+	if hasBodyJSON(mock) {
+		fd.check(mm.matchBodyJSON(req, mock), "bodyJson", compactJSON(mock.BodyJSON), req.Body)
+	}
+	for _, path := range sortedMatchersKeys(mock.BodyJSONPath) {
+		matcher := mock.BodyJSONPath[path]
+		actual := missingValue
+		values, err := utils.GetJSONPathValues(req.Body, path)
+		ok := err == nil && matchJSONValues(matcher, values)
+		if len(values) > 0 {
+			strValues := make([]string, len(values))
+			for i, value := range values {
+				strValues[i] = utils.JSONValueToString(value)
+			}
+			actual = strings.Join(strValues, ", ")
+		}
+		fd.check(ok, "bodyJsonPath."+path, matcher.String(), actual)
+	}
+	fd.checkXML(req, mock)
+	if mock.GraphQL != nil {
+		fd.checkGraphQL(req, mock.GraphQL)
+	}
+
+	return fd.checks, fd.diffs
+}
+
+func copyValues(values definition.Values) definition.Values {
+	result := make(definition.Values, len(values))
+	for key, val := range values {
+		result[key] = val
+	}
+	return result
+}
+
+func getValues(values definition.Values, key string, casesensitive bool) []string {
+	var result []string
+	for rkey, rval := range values {
+		if rkey == key || (!casesensitive && strings.EqualFold(rkey, key)) {
+			result = append(result, rval...)
+		}
+	}
+	return result
+}
+
+func joinValues(values []string) string {
+	if len(values) == 0 {
+		return missingValue
+	}
+	return strings.Join(values, ", ")
+}
+
+func cookieValue(value string, present bool) string {
+	if !present {
+		return missingValue
+	}
+	return value
+}
+
+func compactJSON(data json.RawMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return string(data)
+	}
+	return buf.String()
+}
+
+func sortedValuesKeys(values definition.Values) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedCookiesKeys(cookies definition.Cookies) []string {
+	keys := make([]string, 0, len(cookies))
+	for key := range cookies {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedMatchersKeys(matchers definition.ValueMatchers) []string {
+	keys := make([]string, 0, len(matchers))
+	for key := range matchers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package match
+
+import (
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+//clientCertificateValues returns the client certificate fields by the names used in the tls matchers.
+func clientCertificateValues(cert *definition.ClientCertificate) definition.Values {
+	values := make(definition.Values)
+	if cert == nil {
+		return values
+	}
+	if cert.Subject != "" {
+		values["subject"] = []string{cert.Subject}
+	}
+	if cert.Issuer != "" {
+		values["issuer"] = []string{cert.Issuer}
+	}
+	if len(cert.SANs) > 0 {
+		values["sans"] = cert.SANs
+	}
+	if cert.Fingerprint != "" {
+		values["fingerprint"] = []string{cert.Fingerprint}
+	}
+	return values
+}
+
+//normalizeFingerprint allows defining the fingerprint in upper case and colon separated, as printed by openssl.
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.Replace(fingerprint, ":", "", -1))
+}
+
+func includesValue(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+//checkTLS checks every field of the mock client certificate against the request one.
+func (fd *fieldDiffs) checkTLS(req *definition.ClientCertificate, mock *definition.ClientCertificate) {
+	if req == nil {
+		req = &definition.ClientCertificate{}
+	}
+	if mock.Subject != "" {
+		fd.check(mock.Subject == req.Subject, "tls.subject", mock.Subject, req.Subject)
+	}
+	if mock.Issuer != "" {
+		fd.check(mock.Issuer == req.Issuer, "tls.issuer", mock.Issuer, req.Issuer)
+	}
+	for _, san := range mock.SANs {
+		fd.check(includesValue(req.SANs, san), "tls.sans", san, strings.Join(req.SANs, ", "))
+	}
+	if mock.Fingerprint != "" {
+		fd.check(normalizeFingerprint(mock.Fingerprint) == req.Fingerprint, "tls.fingerprint", mock.Fingerprint, req.Fingerprint)
+	}
+}
+
+//matchTLS checks the request client certificate against the mock tls and tlsMatchers sections.
+func (mm MockMatch) matchTLS(req *definition.Request, mock *definition.Request) bool {
+	if mock.TLS != nil {
+		fd := &fieldDiffs{}
+		fd.checkTLS(req.TLS, mock.TLS)
+		if req.TLS == nil || len(fd.diffs) > 0 {
+			return false
+		}
+	}
+	return matchValuesMap(clientCertificateValues(req.TLS), mock.TLSMatchers, true)
+}
@@ -0,0 +1,87 @@
+package match
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+//matchValue checks whether the value fulfills all the matcher operators, present tells whether the field is in the request.
+func matchValue(matcher definition.ValueMatcher, value string, present bool) bool {
+	if matcher.Absent {
+		return !present
+	}
+	if !present {
+		return false
+	}
+	if matcher.EqualTo != "" && matcher.EqualTo != value {
+		return false
+	}
+	if matcher.Empty && value != "" {
+		return false
+	}
+	if matcher.NotEqualTo != "" && matcher.NotEqualTo == value {
+		return false
+	}
+	if matcher.Contains != "" && !strings.Contains(value, matcher.Contains) {
+		return false
+	}
+	if matcher.Prefix != "" && !strings.HasPrefix(value, matcher.Prefix) {
+		return false
+	}
+	if matcher.Matches != "" {
+		r, err := regexp.Compile(matcher.Matches)
+		if err != nil || !r.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+//matchValues checks whether any of the field values fulfills the matcher, none of them should be equal to notEqualTo.
+func matchValues(matcher definition.ValueMatcher, values []string) bool {
+	if len(values) == 0 {
+		return matchValue(matcher, "", false)
+	}
+	if matcher.NotEqualTo != "" {
+		for _, value := range values {
+			if value == matcher.NotEqualTo {
+				return false
+			}
+		}
+	}
+	for _, value := range values {
+		if matchValue(matcher, value, true) {
+			return true
+		}
+	}
+	return false
+}
+
+//matchValuesMap checks the mock value matchers against the request values, keys are compared case insensitive if needed.
+func matchValuesMap(reqMap definition.Values, matchers definition.ValueMatchers, casesensitive bool) bool {
+	for key, matcher := range matchers {
+		var values []string
+		for rkey, rval := range reqMap {
+			if rkey == key || (!casesensitive && strings.EqualFold(rkey, key)) {
+				values = append(values, rval...)
+			}
+		}
+		if !matchValues(matcher, values) {
+			return false
+		}
+	}
+	return true
+}
+
+//matchCookiesMap checks the mock value matchers against the request cookies.
+func matchCookiesMap(reqMap definition.Cookies, matchers definition.ValueMatchers) bool {
+	for key, matcher := range matchers {
+		value, present := reqMap[key]
+		if !matchValue(matcher, value, present) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,23 @@
+package match
+
+import (
+	"github.com/ryanuber/go-glob"
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+//MatchMessage checks whether the incoming WebSocket message matches the body checks of the reply
+func (mm MockMatch) MatchMessage(message string, reply *definition.WebSocketReply) bool {
+	req := &definition.Request{Body: message}
+	mock := &definition.Request{BodyJSON: reply.BodyJSON, BodyJSONIgnoreExtraFields: reply.BodyJSONIgnoreExtraFields, BodyJSONPath: reply.BodyJSONPath}
+
+	if len(reply.Body) > 0 && !glob.Glob(reply.Body, message) {
+		return false
+	}
+	if hasBodyJSON(mock) && !mm.matchBodyJSON(req, mock) {
+		return false
+	}
+	if len(mock.BodyJSONPath) > 0 && !mm.matchBodyJSONPath(req, mock) {
+		return false
+	}
+	return true
+}
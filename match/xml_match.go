@@ -0,0 +1,59 @@
+package match
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/ryanuber/go-glob"
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//soapAction returns the action of the SOAP request, from the SOAPAction header of SOAP 1.1
+//or the action parameter of the SOAP 1.2 content type.
+func soapAction(req *definition.Request) (string, bool) {
+	if values := getValues(req.Headers, "SOAPAction", false); len(values) > 0 {
+		return strings.Trim(values[0], `"`), true
+	}
+	if values := getValues(req.Headers, "Content-Type", false); len(values) > 0 {
+		if _, params, err := mime.ParseMediaType(values[0]); err == nil {
+			action, found := params["action"]
+			return action, found
+		}
+	}
+	return "", false
+}
+
+//matchSOAPAction checks the request SOAP action, the mock one can use * pattern.
+func (mm MockMatch) matchSOAPAction(req *definition.Request, mock *definition.Request) bool {
+	action, found := soapAction(req)
+	return found && glob.Glob(mock.SOAPAction, action)
+}
+
+//matchBodyXPath checks the values selected by every mock XPath expression in the request body.
+func (mm MockMatch) matchBodyXPath(req *definition.Request, mock *definition.Request) bool {
+	for expr, matcher := range mock.BodyXPath {
+		values, err := utils.GetXPathValues(req.Body, expr, mock.XMLNamespaces)
+		if err != nil || !matchValues(matcher, values) {
+			return false
+		}
+	}
+	return true
+}
+
+//checkXML checks the SOAP action and every XPath expression of the mock against the request.
+func (fd *fieldDiffs) checkXML(req *definition.Request, mock *definition.Request) {
+	if mock.SOAPAction != "" {
+		action, found := soapAction(req)
+		actual := missingValue
+		if found {
+			actual = action
+		}
+		fd.check(found && glob.Glob(mock.SOAPAction, action), "soapAction", mock.SOAPAction, actual)
+	}
+	for _, expr := range sortedMatchersKeys(mock.BodyXPath) {
+		matcher := mock.BodyXPath[expr]
+		values, err := utils.GetXPathValues(req.Body, expr, mock.XMLNamespaces)
+		fd.check(err == nil && matchValues(matcher, values), "bodyXPath."+expr, matcher.String(), joinValues(values))
+	}
+}
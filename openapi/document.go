@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+//ErrNotSpecification when the file is neither OpenAPI 3 nor Swagger 2 document
+var ErrNotSpecification = errors.New("Not an OpenAPI or Swagger document")
+
+//methods are the operations of a path item in the order the mocks are generated
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+//Document is an OpenAPI 3 or Swagger 2 specification read as generic JSON values.
+type Document struct {
+	root map[string]interface{}
+}
+
+//Operation is a single API operation.
+type Operation struct {
+	Method string
+	Path   string
+	Spec   map[string]interface{}
+	//PathParameters are the parameters common for all the operations of the path
+	PathParameters []interface{}
+}
+
+//Name returns the operation id or the method and the path if there is no id.
+func (op Operation) Name() string {
+	if operationID, ok := op.Spec["operationId"].(string); ok && operationID != "" {
+		return operationID
+	}
+	return op.Method + " " + op.Path
+}
+
+//ParseDocument reads a JSON or YAML specification.
+func ParseDocument(data []byte) (*Document, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	root := map[string]interface{}{}
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	doc := &Document{root: root}
+	if !doc.IsOpenAPI3() && !doc.IsSwagger2() {
+		return nil, ErrNotSpecification
+	}
+	return doc, nil
+}
+
+//IsOpenAPI3 checks whether the document is an OpenAPI 3 one.
+func (doc *Document) IsOpenAPI3() bool {
+	version, _ := doc.root["openapi"].(string)
+	return strings.HasPrefix(version, "3.")
+}
+
+//IsSwagger2 checks whether the document is a Swagger 2 one.
+func (doc *Document) IsSwagger2() bool {
+	version, _ := doc.root["swagger"].(string)
+	return strings.HasPrefix(version, "2.")
+}
+
+//BasePath returns the path prefix of all the operations.
+//It is the path of the first server in OpenAPI 3 and the basePath in Swagger 2.
+func (doc *Document) BasePath() string {
+	basePath := ""
+	if doc.IsSwagger2() {
+		basePath, _ = doc.root["basePath"].(string)
+	} else if servers, ok := doc.root["servers"].([]interface{}); ok && len(servers) > 0 {
+		server, _ := servers[0].(map[string]interface{})
+		if serverURL, ok := server["url"].(string); ok {
+			if u, err := url.Parse(serverURL); err == nil {
+				basePath = u.Path
+			}
+		}
+	}
+	return strings.TrimRight(basePath, "/")
+}
+
+//Operations returns the document operations. The paths without parameters go first,
+//so that they are matched before the parametrized ones.
+func (doc *Document) Operations() []Operation {
+	paths, _ := doc.root["paths"].(map[string]interface{})
+	keys := make([]string, 0, len(paths))
+	for path := range paths {
+		keys = append(keys, path)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		pi, pj := strings.Count(keys[i], "{"), strings.Count(keys[j], "{")
+		if pi != pj {
+			return pi < pj
+		}
+		return keys[i] < keys[j]
+	})
+
+	operations := []Operation{}
+	for _, path := range keys {
+		item, _ := doc.Resolve(paths[path]).(map[string]interface{})
+		parameters, _ := item["parameters"].([]interface{})
+		for _, method := range methods {
+			if spec, ok := item[method].(map[string]interface{}); ok {
+				operations = append(operations, Operation{Method: strings.ToUpper(method), Path: path, Spec: spec, PathParameters: parameters})
+			}
+		}
+	}
+	return operations
+}
+
+//FindOperation returns the operation with the given name, see Operation.Name.
+func (doc *Document) FindOperation(name string) (Operation, bool) {
+	for _, op := range doc.Operations() {
+		if op.Name() == name || (op.Method+" "+op.Path) == name {
+			return op, true
+		}
+	}
+	return Operation{}, false
+}
+
+//Resolve follows the local $ref references of a value, the remote ones are not supported.
+func (doc *Document) Resolve(value interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return value
+		}
+		value = doc.lookup(ref)
+	}
+	return nil
+}
+
+//lookup returns the value of a JSON pointer reference like #/components/schemas/Pet
+func (doc *Document) lookup(ref string) interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var current interface{} = doc.root
+	for _, part := range strings.Split(ref[2:], "/") {
+		part = strings.Replace(strings.Replace(part, "~1", "/", -1), "~0", "~", -1)
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
@@ -0,0 +1,284 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//StatusHeader is the request header used for selecting a response different from the default one, e.g. Prefer: code=404
+const StatusHeader = "Prefer"
+
+var (
+	pathParamRegex = regexp.MustCompile(`\{([^}/]+)\}`)
+	//yamlVersionRegex finds the top-level version field of the YAML specifications, the nested fields are indented so they don't match
+	yamlVersionRegex = regexp.MustCompile(`(?m)^["']?(openapi["']?[ \t]*:[ \t]*["']?3\.|swagger["']?[ \t]*:[ \t]*["']?2\.)`)
+)
+
+//Reader generates mock definitions from OpenAPI 3 and Swagger 2 documents in JSON or YAML format.
+type Reader struct {
+}
+
+//CanRead return true if the file has the top-level openapi 3.x or swagger 2.x version field.
+//The fields of the mocks, like a response body serving a specification, are not taken into account.
+func (r Reader) CanRead(filename string, content []byte) bool {
+	switch filepath.Ext(filename) {
+	case ".json", ".yaml", ".yml":
+		if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+			return hasJSONVersion(trimmed)
+		}
+		return yamlVersionRegex.Match(content)
+	}
+	return false
+}
+
+//hasJSONVersion walks the top-level fields of the JSON document until it finds the version one, the other values are skipped
+func hasJSONVersion(content []byte) bool {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if _, err := decoder.Token(); err != nil {
+		return false
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return false
+		}
+		version := ""
+		json.Unmarshal(value, &version)
+		switch token {
+		case "openapi":
+			return strings.HasPrefix(version, "3.")
+		case "swagger":
+			return strings.HasPrefix(version, "2.")
+		}
+	}
+	return false
+}
+
+//ReadMultiple returns a mock definition for every operation and response status code in the document
+func (r Reader) ReadMultiple(filename string, content []byte) ([]definition.Mock, error) {
+	logging.Printf("Loading OpenAPI config: %s\n", filename)
+	doc, err := ParseDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	mocks := BuildMocks(doc)
+	for i := range mocks {
+		mocks[i].OpenAPI.Spec = filename
+	}
+	return mocks, nil
+}
+
+//BuildMocks generates the mock definitions of the document operations.
+//The default response of an operation is the first successful one, the others are served
+//when their status code is requested with the Prefer header.
+func BuildMocks(doc *Document) []definition.Mock {
+	mocks := []definition.Mock{}
+	basePath := doc.BasePath()
+	for _, op := range doc.Operations() {
+		responses, _ := op.Spec["responses"].(map[string]interface{})
+		statusCodes := sortedStatusCodes(responses)
+		for i, status := range statusCodes {
+			mock := definition.Mock{}
+			mock.Name = fmt.Sprintf("%s:%d", operationName(op), status.code)
+			mock.Description, _ = op.Spec["summary"].(string)
+			mock.Request.Method = op.Method
+			mock.Request.Path = basePath + pathParamRegex.ReplaceAllString(op.Path, ":$1")
+			mock.OpenAPI = &definition.OpenAPI{Operation: op.Name()}
+			if i > 0 {
+				mock.Request.HeaderMatchers = definition.ValueMatchers{
+					StatusHeader: {Matches: fmt.Sprintf(`(^|[\s,;])code=%d\b`, status.code)},
+				}
+				mock.Control.Priority = 1
+			}
+			response, _ := doc.Resolve(responses[status.key]).(map[string]interface{})
+			mock.Response = buildResponse(doc, op, status.code, response)
+			mocks = append(mocks, mock)
+		}
+	}
+	return mocks
+}
+
+type statusCode struct {
+	key  string
+	code int
+}
+
+//sortedStatusCodes returns the response status codes, the successful ones go first.
+//The default response is used only if there are no other ones.
+func sortedStatusCodes(responses map[string]interface{}) []statusCode {
+	codes := []statusCode{}
+	for key := range responses {
+		// status code ranges like 2XX are served with the first code of the range
+		code, err := strconv.Atoi(strings.Replace(strings.ToUpper(key), "XX", "00", 1))
+		if err == nil {
+			codes = append(codes, statusCode{key: key, code: code})
+		}
+	}
+	if _, ok := responses["default"]; ok && len(codes) == 0 {
+		codes = append(codes, statusCode{key: "default", code: 200})
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		si, sj := codes[i].code/100 == 2, codes[j].code/100 == 2
+		if si != sj {
+			return si
+		}
+		return codes[i].code < codes[j].code
+	})
+	return codes
+}
+
+func operationName(op Operation) string {
+	if operationID, ok := op.Spec["operationId"].(string); ok && operationID != "" {
+		return operationID
+	}
+	return strings.ToLower(op.Method) + "-" + utils.CleanName(op.Path)
+}
+
+func buildResponse(doc *Document, op Operation, code int, response map[string]interface{}) definition.Response {
+	res := definition.Response{StatusCode: code}
+	res.Headers = make(definition.Values)
+	for name, value := range mapValue(response["headers"]) {
+		header, _ := doc.Resolve(value).(map[string]interface{})
+		if example, ok := headerExample(doc, header); ok {
+			res.Headers[name] = []string{example}
+		}
+	}
+
+	var contentType, body string
+	var found bool
+	if doc.IsSwagger2() {
+		contentType, body, found = swagger2Body(doc, op, response)
+	} else {
+		contentType, body, found = openAPI3Body(doc, response)
+	}
+	if found {
+		if contentType == "*/*" {
+			contentType = "application/json"
+		}
+		res.Headers["Content-Type"] = []string{contentType}
+		res.Body = body
+	}
+	return res
+}
+
+func openAPI3Body(doc *Document, response map[string]interface{}) (string, string, bool) {
+	content := mapValue(response["content"])
+	contentType, found := selectContentType(keys(content))
+	if !found {
+		return "", "", false
+	}
+	media, _ := content[contentType].(map[string]interface{})
+	if example, ok := media["example"]; ok {
+		return contentType, formatExample(contentType, example), true
+	}
+	examples := mapValue(media["examples"])
+	if names := keys(examples); len(names) > 0 {
+		example, _ := doc.Resolve(examples[names[0]]).(map[string]interface{})
+		return contentType, formatExample(contentType, example["value"]), true
+	}
+	if schema, ok := media["schema"]; ok && isJSON(contentType) {
+		return contentType, doc.Synthesize(schema), true
+	}
+	return contentType, "", true
+}
+
+func swagger2Body(doc *Document, op Operation, response map[string]interface{}) (string, string, bool) {
+	examples := mapValue(response["examples"])
+	if contentType, found := selectContentType(keys(examples)); found {
+		return contentType, formatExample(contentType, examples[contentType]), true
+	}
+	schema, ok := response["schema"]
+	if !ok {
+		return "", "", false
+	}
+	produces := stringsValue(op.Spec["produces"])
+	if len(produces) == 0 {
+		produces = stringsValue(doc.root["produces"])
+	}
+	contentType, found := selectContentType(produces)
+	if !found {
+		contentType = "application/json"
+	}
+	if !isJSON(contentType) {
+		return contentType, "", true
+	}
+	return contentType, doc.Synthesize(schema), true
+}
+
+func headerExample(doc *Document, header map[string]interface{}) (string, bool) {
+	example, ok := header["example"]
+	if !ok {
+		schema, _ := doc.Resolve(header["schema"]).(map[string]interface{})
+		example, ok = schema["example"]
+	}
+	if !ok {
+		return "", false
+	}
+	if value, isString := example.(string); isString {
+		return value, true
+	}
+	return marshal(example), true
+}
+
+//selectContentType prefers JSON content types, as the bodies of the others can't be synthesized
+func selectContentType(contentTypes []string) (string, bool) {
+	for _, contentType := range contentTypes {
+		if isJSON(contentType) {
+			return contentType, true
+		}
+	}
+	if len(contentTypes) > 0 {
+		return contentTypes[0], true
+	}
+	return "", false
+}
+
+func isJSON(contentType string) bool {
+	return strings.Contains(contentType, "json") || contentType == "*/*"
+}
+
+func formatExample(contentType string, example interface{}) string {
+	if value, ok := example.(string); ok && !isJSON(contentType) {
+		return value
+	}
+	return marshal(example)
+}
+
+func mapValue(value interface{}) map[string]interface{} {
+	result, _ := value.(map[string]interface{})
+	return result
+}
+
+func stringsValue(value interface{}) []string {
+	items, _ := value.([]interface{})
+	result := []string{}
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func keys(values map[string]interface{}) []string {
+	result := make([]string, 0, len(values))
+	for key := range values {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,172 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+const openAPI3Document = `
+openapi: "3.0.1"
+servers:
+  - url: https://api.example.com/v1/
+paths:
+  /users/{userId}/orders/{orderId}:
+    get:
+      operationId: getOrder
+      responses:
+        "404":
+          description: Not found
+        "200":
+          description: The order
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Order"
+  /users/me:
+    delete:
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    Order:
+      allOf:
+        - $ref: "#/components/schemas/Entity"
+        - type: object
+          properties:
+            total:
+              type: number
+            status:
+              type: string
+              enum: [open, closed]
+            items:
+              type: array
+              items:
+                type: object
+                properties:
+                  product_name:
+                    type: string
+    Entity:
+      properties:
+        id:
+          type: string
+          format: uuid
+`
+
+const swagger2Document = `{
+	"swagger": "2.0",
+	"basePath": "/api",
+	"produces": ["application/json"],
+	"paths": {
+		"/pets/{id}": {
+			"get": {
+				"operationId": "getPet",
+				"responses": {
+					"200": {
+						"description": "The pet",
+						"schema": {"$ref": "#/definitions/Pet"},
+						"examples": {"application/json": {"id": 1, "name": "Rex"}}
+					},
+					"default": {
+						"description": "Error"
+					}
+				}
+			}
+		}
+	},
+	"definitions": {
+		"Pet": {"type": "object", "properties": {"id": {"type": "integer"}}}
+	}
+}`
+
+func getMock(mocks []definition.Mock, name string) definition.Mock {
+	for _, mock := range mocks {
+		if mock.Name == name {
+			return mock
+		}
+	}
+	return definition.Mock{}
+}
+
+func TestBuildMocks_OpenAPI3(t *testing.T) {
+	doc, err := ParseDocument([]byte(openAPI3Document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mocks := BuildMocks(doc)
+	if len(mocks) != 3 {
+		t.Fatal("A mock should be generated for every operation and status code", len(mocks))
+	}
+	if mocks[0].Name != "delete-users-me:204" {
+		t.Error("The paths without parameters should go first", mocks[0].Name)
+	}
+
+	mock := getMock(mocks, "getOrder:200")
+	if mock.Request.Method != "GET" || mock.Request.Path != "/v1/users/:userId/orders/:orderId" {
+		t.Error("Unexpected request", mock.Request.Method, mock.Request.Path)
+	}
+	if len(mock.Request.HeaderMatchers) > 0 || mock.Control.Priority != 0 {
+		t.Error("The successful response should be the default one")
+	}
+	expectedBody := `{"id": "{{fake.UUID}}", "items": [{"product_name": "{{fake.FullName}}"}], "status": "open", "total": {{fake.Float(1000)}}}`
+	if mock.Response.Body != expectedBody {
+		t.Error("Unexpected synthesized body", mock.Response.Body)
+	}
+	if mock.Response.Headers["Content-Type"][0] != "application/json" {
+		t.Error("Unexpected content type", mock.Response.Headers)
+	}
+
+	mock = getMock(mocks, "getOrder:404")
+	if mock.Request.HeaderMatchers[StatusHeader].Matches == "" || mock.Control.Priority != 1 {
+		t.Error("The other responses should be selected with the status header", mock.Request.HeaderMatchers)
+	}
+	if mock.Response.StatusCode != 404 || mock.Response.Body != "" {
+		t.Error("Unexpected response", mock.Response.StatusCode, mock.Response.Body)
+	}
+}
+
+func TestBuildMocks_Swagger2(t *testing.T) {
+	doc, err := ParseDocument([]byte(swagger2Document))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mocks := BuildMocks(doc)
+	if len(mocks) != 1 {
+		t.Fatal("The default response should be used only if there are no other ones", len(mocks))
+	}
+	if mocks[0].Request.Path != "/api/pets/:id" {
+		t.Error("Unexpected path", mocks[0].Request.Path)
+	}
+	if mocks[0].Response.Body != `{"id":1,"name":"Rex"}` {
+		t.Error("The example should be used as body", mocks[0].Response.Body)
+	}
+}
+
+func TestParseDocument_NotSpecification(t *testing.T) {
+	if _, err := ParseDocument([]byte(`{"request": {"method": "GET"}}`)); err != ErrNotSpecification {
+		t.Error("The mock definitions should not be read as specification", err)
+	}
+}
+
+func TestCanRead(t *testing.T) {
+	reader := Reader{}
+	if !reader.CanRead("pets.yaml", []byte(openAPI3Document)) || !reader.CanRead("pets.json", []byte(`{"swagger":"2.0","paths":{}}`)) {
+		t.Error("The specifications should be read")
+	}
+	mock := []byte(`{"request": {"method": "GET", "path": "/pets"}, "openapi": {"spec": "pets.yaml", "operation": "listPets"}}`)
+	if reader.CanRead("pets.json", mock) {
+		t.Error("The mock definitions linked to operations should not be read as specifications")
+	}
+	specMock := []byte(`{"request": {"path": "/spec.json"}, "response": {"body": "{\"swagger\": \"2.0\", \"openapi\": \"3.0.1\"}"}}`)
+	if reader.CanRead("spec.json", specMock) {
+		t.Error("The mocks serving a specification should not be read as specifications")
+	}
+	yamlSpecMock := []byte("request:\n  path: /spec.yaml\nresponse:\n  body: |\n    openapi: 3.0.1\n    swagger: 2.0\n")
+	if reader.CanRead("spec.yaml", yamlSpecMock) {
+		t.Error("The YAML mocks serving a specification should not be read as specifications")
+	}
+	if reader.CanRead("pets.har", []byte(openAPI3Document)) {
+		t.Error("Only the JSON and YAML files should be read")
+	}
+}
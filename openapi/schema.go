@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//maxSchemaDepth limits the nesting of the synthesized values, as the schemas can be recursive
+const maxSchemaDepth = 6
+
+//fakeStringsByFormat are the fake data tags used for the string formats
+var fakeStringsByFormat = map[string]string{
+	"email":     "{{fake.EmailAddress}}",
+	"uuid":      "{{fake.UUID}}",
+	"ipv4":      "{{fake.IPv4}}",
+	"date":      "2018-01-01",
+	"date-time": "2018-01-01T00:00:00Z",
+	"uri":       "http://example.com",
+	"hostname":  "example.com",
+	"password":  "{{fake.SimplePassword}}",
+}
+
+//fakeStringsByName are the fake data tags used for the string properties containing these words,
+//they are checked in this order
+var fakeStringsByName = []struct {
+	word string
+	tag  string
+}{
+	{"email", "{{fake.EmailAddress}}"},
+	{"firstname", "{{fake.FirstName}}"},
+	{"lastname", "{{fake.LastName}}"},
+	{"username", "{{fake.UserName}}"},
+	{"fullname", "{{fake.FullName}}"},
+	{"phone", "{{fake.Phone}}"},
+	{"city", "{{fake.City}}"},
+	{"country", "{{fake.Country}}"},
+	{"street", "{{fake.StreetAddress}}"},
+	{"address", "{{fake.StreetAddress}}"},
+	{"zip", "{{fake.Zip}}"},
+	{"company", "{{fake.Company}}"},
+	{"color", "{{fake.Color}}"},
+	{"currency", "{{fake.CurrencyCode}}"},
+	{"description", "{{fake.Sentence}}"},
+	{"title", "{{fake.Sentence}}"},
+	{"name", "{{fake.FullName}}"},
+}
+
+//Synthesize builds a JSON value matching the schema. The values are fake data tags,
+//so they differ on every request.
+func (doc *Document) Synthesize(schema interface{}) string {
+	return doc.synthesize(schema, "", 0)
+}
+
+func (doc *Document) synthesize(value interface{}, name string, depth int) string {
+	schema, _ := doc.Resolve(value).(map[string]interface{})
+	if schema == nil || depth > maxSchemaDepth {
+		return "null"
+	}
+	if example, ok := schema["example"]; ok {
+		return marshal(example)
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return marshal(enum[0])
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if schemas, ok := schema[key].([]interface{}); ok && len(schemas) > 0 {
+			return doc.synthesize(schemas[0], name, depth+1)
+		}
+	}
+	if schemas, ok := schema["allOf"].([]interface{}); ok {
+		return doc.synthesizeObject(doc.mergeAllOf(schemas), depth)
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return doc.synthesizeObject(schema, depth)
+	case "array":
+		return "[" + doc.synthesize(schema["items"], name, depth+1) + "]"
+	case "integer":
+		return fmt.Sprintf("{{fake.Int(%d)}}", maximum(schema, 1000))
+	case "number":
+		return fmt.Sprintf("{{fake.Float(%d)}}", maximum(schema, 1000))
+	case "boolean":
+		return "true"
+	case "string":
+		return marshal(fakeString(schema, name))
+	}
+	return "null"
+}
+
+func (doc *Document) synthesizeObject(schema map[string]interface{}, depth int) string {
+	properties, _ := schema["properties"].(map[string]interface{})
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		fields[i] = marshal(key) + ": " + doc.synthesize(properties[key], key, depth+1)
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+//mergeAllOf combines the properties of all the schemas into a single object schema.
+func (doc *Document) mergeAllOf(schemas []interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for _, item := range schemas {
+		schema, _ := doc.Resolve(item).(map[string]interface{})
+		if nested, ok := schema["allOf"].([]interface{}); ok {
+			schema = doc.mergeAllOf(nested)
+		}
+		if itemProperties, ok := schema["properties"].(map[string]interface{}); ok {
+			for key, value := range itemProperties {
+				properties[key] = value
+			}
+		}
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func schemaType(schema map[string]interface{}) string {
+	switch schemaType := schema["type"].(type) {
+	case string:
+		return schemaType
+	case []interface{}:
+		// JSON schema allows a list of types, e.g. ["string", "null"]
+		for _, item := range schemaType {
+			if item, ok := item.(string); ok && item != "null" {
+				return item
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+func maximum(schema map[string]interface{}, defaultValue int) int {
+	if max, ok := schema["maximum"].(float64); ok && max >= 1 {
+		return int(max)
+	}
+	return defaultValue
+}
+
+//FakeStringTag returns the fake data tag used for the string values of the properties with the given name
+func FakeStringTag(name string) string {
+	return fakeString(nil, name)
+}
+
+func fakeString(schema map[string]interface{}, name string) string {
+	format, _ := schema["format"].(string)
+	if tag, ok := fakeStringsByFormat[format]; ok {
+		return tag
+	}
+	name = strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	for _, item := range fakeStringsByName {
+		if strings.Contains(name, item.word) {
+			return item.tag
+		}
+	}
+	if strings.HasSuffix(name, "id") {
+		return "{{fake.UUID}}"
+	}
+	return "{{fake.Word}}"
+}
+
+func marshal(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
@@ -0,0 +1,221 @@
+package openapi
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"time"
+)
+
+//formatRegexes are used for validating the string formats, the unknown formats are not validated
+var formatRegexes = map[string]*regexp.Regexp{
+	"email": regexp.MustCompile(`^[^@\s]+@[^@\s]+$`),
+	"uuid":  regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+	"ipv4":  regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}$`),
+}
+
+//ValidateSchema checks the value against the schema and returns the violations found.
+//The value should be decoded from JSON, the field is the name of the value used in the violations.
+func (doc *Document) ValidateSchema(schema interface{}, value interface{}, field string) []string {
+	return doc.validateSchema(schema, value, field, 0)
+}
+
+func (doc *Document) validateSchema(value interface{}, data interface{}, field string, depth int) []string {
+	schema, _ := doc.Resolve(value).(map[string]interface{})
+	if schema == nil || depth > 32 {
+		return nil
+	}
+
+	if data == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || schemaType(schema) == "" || allowsNull(schema) {
+			return nil
+		}
+		return []string{fmt.Sprintf("%s: should not be null", field)}
+	}
+
+	violations := []string{}
+	if schemas, ok := schema["allOf"].([]interface{}); ok {
+		for _, item := range schemas {
+			violations = append(violations, doc.validateSchema(item, data, field, depth+1)...)
+		}
+	}
+	if schemas, ok := schema["anyOf"].([]interface{}); ok && len(schemas) > 0 && doc.countMatches(schemas, data, field, depth) == 0 {
+		violations = append(violations, fmt.Sprintf("%s: should match one of the schemas in anyOf", field))
+	}
+	if schemas, ok := schema["oneOf"].([]interface{}); ok && len(schemas) > 0 {
+		if matches := doc.countMatches(schemas, data, field, depth); matches == 0 {
+			violations = append(violations, fmt.Sprintf("%s: should match one of the schemas in oneOf", field))
+		} else if matches > 1 {
+			violations = append(violations, fmt.Sprintf("%s: should match exactly one of the schemas in oneOf, but matches %d", field, matches))
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 && !includesEnumValue(enum, data) {
+		violations = append(violations, fmt.Sprintf("%s: should be one of %s", field, marshal(enum)))
+	}
+
+	expectedType := schemaType(schema)
+	if expectedType != "" && !hasType(data, expectedType) {
+		return append(violations, fmt.Sprintf("%s: should be %s", field, expectedType))
+	}
+
+	switch data := data.(type) {
+	case map[string]interface{}:
+		violations = append(violations, doc.validateObject(schema, data, field, depth)...)
+	case []interface{}:
+		if min, ok := schema["minItems"].(float64); ok && float64(len(data)) < min {
+			violations = append(violations, fmt.Sprintf("%s: should have at least %v items", field, min))
+		}
+		if max, ok := schema["maxItems"].(float64); ok && float64(len(data)) > max {
+			violations = append(violations, fmt.Sprintf("%s: should have at most %v items", field, max))
+		}
+		if items, ok := schema["items"]; ok {
+			for i, item := range data {
+				violations = append(violations, doc.validateSchema(items, item, fmt.Sprintf("%s[%d]", field, i), depth+1)...)
+			}
+		}
+	case string:
+		violations = append(violations, validateString(schema, data, field)...)
+	case float64:
+		violations = append(violations, validateNumber(schema, data, field)...)
+	}
+	return violations
+}
+
+//countMatches returns the number of the schemas the value is valid against
+func (doc *Document) countMatches(schemas []interface{}, data interface{}, field string, depth int) int {
+	matches := 0
+	for _, item := range schemas {
+		if len(doc.validateSchema(item, data, field, depth+1)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func (doc *Document) validateObject(schema map[string]interface{}, data map[string]interface{}, field string, depth int) []string {
+	violations := []string{}
+	for _, name := range stringsValue(schema["required"]) {
+		if _, ok := data[name]; !ok {
+			violations = append(violations, fmt.Sprintf("%s.%s: is required", field, name))
+		}
+	}
+	properties := mapValue(schema["properties"])
+	for _, name := range keys(data) {
+		if propertySchema, ok := properties[name]; ok {
+			violations = append(violations, doc.validateSchema(propertySchema, data[name], field+"."+name, depth+1)...)
+		} else if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+			violations = append(violations, fmt.Sprintf("%s.%s: is not allowed", field, name))
+		} else if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			violations = append(violations, doc.validateSchema(additional, data[name], field+"."+name, depth+1)...)
+		}
+	}
+	return violations
+}
+
+func validateString(schema map[string]interface{}, data string, field string) []string {
+	violations := []string{}
+	length := float64(len([]rune(data)))
+	if min, ok := schema["minLength"].(float64); ok && length < min {
+		violations = append(violations, fmt.Sprintf("%s: should be at least %v characters long", field, min))
+	}
+	if max, ok := schema["maxLength"].(float64); ok && length > max {
+		violations = append(violations, fmt.Sprintf("%s: should be at most %v characters long", field, max))
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if r, err := regexp.Compile(pattern); err == nil && !r.MatchString(data) {
+			violations = append(violations, fmt.Sprintf("%s: should match %s", field, pattern))
+		}
+	}
+	format, _ := schema["format"].(string)
+	valid := true
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, data)
+		valid = err == nil
+	case "date":
+		_, err := time.Parse("2006-01-02", data)
+		valid = err == nil
+	default:
+		if r, ok := formatRegexes[format]; ok {
+			valid = r.MatchString(data)
+		}
+	}
+	if !valid {
+		violations = append(violations, fmt.Sprintf("%s: should be a valid %s", field, format))
+	}
+	return violations
+}
+
+func validateNumber(schema map[string]interface{}, data float64, field string) []string {
+	violations := []string{}
+	if min, ok := schema["minimum"].(float64); ok {
+		// OpenAPI 3.0 and Swagger 2 define exclusiveMinimum as boolean, the newer versions as number
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); (exclusive && data <= min) || data < min {
+			violations = append(violations, fmt.Sprintf("%s: should be greater than%s %v", field, orEqual(exclusive), min))
+		}
+	}
+	if min, ok := schema["exclusiveMinimum"].(float64); ok && data <= min {
+		violations = append(violations, fmt.Sprintf("%s: should be greater than %v", field, min))
+	}
+	if max, ok := schema["maximum"].(float64); ok {
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); (exclusive && data >= max) || data > max {
+			violations = append(violations, fmt.Sprintf("%s: should be less than%s %v", field, orEqual(exclusive), max))
+		}
+	}
+	if max, ok := schema["exclusiveMaximum"].(float64); ok && data >= max {
+		violations = append(violations, fmt.Sprintf("%s: should be less than %v", field, max))
+	}
+	return violations
+}
+
+func orEqual(exclusive bool) string {
+	if exclusive {
+		return ""
+	}
+	return " or equal to"
+}
+
+func allowsNull(schema map[string]interface{}) bool {
+	types, _ := schema["type"].([]interface{})
+	for _, item := range types {
+		if item == "null" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasType(data interface{}, expectedType string) bool {
+	switch expectedType {
+	case "object":
+		_, ok := data.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := data.([]interface{})
+		return ok
+	case "string":
+		_, ok := data.(string)
+		return ok
+	case "boolean":
+		_, ok := data.(bool)
+		return ok
+	case "number":
+		_, ok := data.(float64)
+		return ok
+	case "integer":
+		number, ok := data.(float64)
+		return ok && number == math.Trunc(number)
+	}
+	return true
+}
+
+func includesEnumValue(enum []interface{}, data interface{}) bool {
+	value := marshal(data)
+	for _, item := range enum {
+		if marshal(item) == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+//ErrOperationNotFound when the mock is linked to an operation missing in the document
+var ErrOperationNotFound = errors.New("OpenAPI operation not found")
+
+//Validator checks the requests against the OpenAPI operations linked to the mocks.
+type Validator struct {
+	ConfigPath string
+	documents  map[string]cachedDocument
+	sync.Mutex
+}
+
+type cachedDocument struct {
+	doc     *Document
+	modTime time.Time
+}
+
+//NewValidator returns a validator loading the documents relative to the config path
+func NewValidator(configPath string) *Validator {
+	return &Validator{ConfigPath: configPath, documents: make(map[string]cachedDocument)}
+}
+
+//Validate returns the violations of the operation parameters and JSON body schema found in the request.
+func (v *Validator) Validate(req *definition.Request, link *definition.OpenAPI) ([]string, error) {
+	doc, err := v.getDocument(link.Spec)
+	if err != nil {
+		return nil, err
+	}
+	op, found := doc.FindOperation(link.Operation)
+	if !found {
+		return nil, ErrOperationNotFound
+	}
+
+	violations := []string{}
+	pathParams := extractPathParams(doc.BasePath(), op.Path, req.Path)
+	for _, param := range doc.parameters(op) {
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		if in == "body" {
+			violations = append(violations, doc.validateBody(param["schema"], isRequired(param), req.Body)...)
+			continue
+		}
+		values, present := parameterValues(req, pathParams, in, name)
+		if !present {
+			if isRequired(param) || in == "path" {
+				violations = append(violations, fmt.Sprintf("%s.%s: is required", in, name))
+			}
+			continue
+		}
+		schema := param
+		if paramSchema, ok := param["schema"]; ok {
+			schema, _ = doc.Resolve(paramSchema).(map[string]interface{})
+		}
+		violations = append(violations, doc.ValidateSchema(schema, doc.convertParameter(schema, values), in+"."+name)...)
+	}
+
+	if requestBody, ok := doc.Resolve(op.Spec["requestBody"]).(map[string]interface{}); ok {
+		content := mapValue(requestBody["content"])
+		if contentType, found := selectContentType(keys(content)); found && isJSON(contentType) {
+			media := mapValue(content[contentType])
+			violations = append(violations, doc.validateBody(media["schema"], isRequired(requestBody), req.Body)...)
+		} else if isRequired(requestBody) && req.Body == "" {
+			violations = append(violations, "body: is required")
+		}
+	}
+	return violations, nil
+}
+
+func (v *Validator) getDocument(spec string) (*Document, error) {
+	if !filepath.IsAbs(spec) {
+		spec = filepath.Join(v.ConfigPath, spec)
+	}
+	info, err := os.Stat(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	v.Lock()
+	defer v.Unlock()
+	if cached, ok := v.documents[spec]; ok && cached.modTime.Equal(info.ModTime()) {
+		return cached.doc, nil
+	}
+	buf, err := ioutil.ReadFile(spec)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := ParseDocument(buf)
+	if err != nil {
+		return nil, err
+	}
+	v.documents[spec] = cachedDocument{doc: doc, modTime: info.ModTime()}
+	return doc, nil
+}
+
+//parameters returns the path and the operation parameters, the operation ones override the path ones with the same name
+func (doc *Document) parameters(op Operation) []map[string]interface{} {
+	parameters := []map[string]interface{}{}
+	indexes := map[string]int{}
+	operationParameters, _ := op.Spec["parameters"].([]interface{})
+	for _, item := range append(append([]interface{}{}, op.PathParameters...), operationParameters...) {
+		param, ok := doc.Resolve(item).(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%v:%v", param["in"], param["name"])
+		if i, ok := indexes[key]; ok {
+			parameters[i] = param
+		} else {
+			indexes[key] = len(parameters)
+			parameters = append(parameters, param)
+		}
+	}
+	return parameters
+}
+
+func (doc *Document) validateBody(schema interface{}, required bool, body string) []string {
+	if strings.TrimSpace(body) == "" {
+		if required {
+			return []string{"body: is required"}
+		}
+		return nil
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return []string{"body: should be valid JSON"}
+	}
+	return doc.ValidateSchema(schema, data, "body")
+}
+
+//convertParameter converts the string values to the schema type, arrays can be passed as comma separated value as well
+func (doc *Document) convertParameter(schema map[string]interface{}, values []string) interface{} {
+	if schemaType(schema) == "array" {
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		items, _ := doc.Resolve(schema["items"]).(map[string]interface{})
+		result := make([]interface{}, len(values))
+		for i, value := range values {
+			result[i] = convertValue(items, value)
+		}
+		return result
+	}
+	return convertValue(schema, values[0])
+}
+
+func convertValue(schema map[string]interface{}, value string) interface{} {
+	switch schemaType(schema) {
+	case "integer", "number":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+	return value
+}
+
+func parameterValues(req *definition.Request, pathParams map[string]string, in string, name string) ([]string, bool) {
+	switch in {
+	case "path":
+		value, ok := pathParams[name]
+		return []string{value}, ok
+	case "query":
+		values, ok := req.QueryStringParameters[name]
+		return values, ok && len(values) > 0
+	case "header":
+		for header, values := range req.Headers {
+			if strings.EqualFold(header, name) && len(values) > 0 {
+				return values, true
+			}
+		}
+	case "cookie":
+		value, ok := req.Cookies[name]
+		return []string{value}, ok
+	}
+	return nil, false
+}
+
+//extractPathParams matches the request path with the path template with or without the base path
+func extractPathParams(basePath string, template string, path string) map[string]string {
+	params := map[string]string{}
+	names := []string{}
+	pattern := regexp.QuoteMeta(template)
+	pattern = regexp.MustCompile(`\\\{([^}/]+)\\\}`).ReplaceAllStringFunc(pattern, func(raw string) string {
+		names = append(names, raw[2:len(raw)-2])
+		return "([^/]+)"
+	})
+	r := regexp.MustCompile("^(?:" + regexp.QuoteMeta(basePath) + ")?" + pattern + "/?$")
+	matches := r.FindStringSubmatch(path)
+	for i, name := range names {
+		if i+1 < len(matches) {
+			params[name] = matches[i+1]
+		}
+	}
+	return params
+}
+
+func isRequired(value map[string]interface{}) bool {
+	required, _ := value["required"].(bool)
+	return required
+}
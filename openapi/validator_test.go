@@ -0,0 +1,161 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+const validationDocument = `
+openapi: "3.0.0"
+servers:
+  - url: /v1
+paths:
+  /users/{userId}:
+    parameters:
+      - name: userId
+        in: path
+        required: true
+        schema:
+          type: integer
+    put:
+      operationId: updateUser
+      parameters:
+        - name: notify
+          in: query
+          schema:
+            type: boolean
+        - name: X-Request-Id
+          in: header
+          required: true
+          schema:
+            type: string
+            format: uuid
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/User"
+      responses:
+        "200":
+          description: Updated
+components:
+  schemas:
+    User:
+      type: object
+      required: [name, email]
+      additionalProperties: false
+      properties:
+        name:
+          type: string
+          minLength: 2
+        email:
+          type: string
+          format: email
+        age:
+          type: integer
+          minimum: 0
+        roles:
+          type: array
+          items:
+            type: string
+            enum: [user, admin]
+`
+
+func getValidator(t *testing.T) (*Validator, func()) {
+	dir, err := ioutil.TempDir("", "openapi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "users.yaml"), []byte(validationDocument), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return NewValidator(dir), func() { os.RemoveAll(dir) }
+}
+
+func TestValidator_ValidRequest(t *testing.T) {
+	validator, cleanup := getValidator(t)
+	defer cleanup()
+
+	req := &definition.Request{Method: "PUT", Path: "/v1/users/12"}
+	req.QueryStringParameters = definition.Values{"notify": []string{"true"}}
+	req.Headers = definition.Values{"X-Request-Id": []string{"0b6c4a2e-6f43-4c9e-a4a5-2f1f3f6b9f1d"}}
+	req.Body = `{"name": "John", "email": "john@example.com", "age": 33, "roles": ["admin"]}`
+
+	violations, err := validator.Validate(req, &definition.OpenAPI{Spec: "users.yaml", Operation: "updateUser"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) > 0 {
+		t.Error("The request should be valid", violations)
+	}
+}
+
+func TestValidator_InvalidRequest(t *testing.T) {
+	validator, cleanup := getValidator(t)
+	defer cleanup()
+
+	req := &definition.Request{Method: "PUT", Path: "/v1/users/john"}
+	req.QueryStringParameters = definition.Values{"notify": []string{"maybe"}}
+	req.Body = `{"name": "J", "age": 1.5, "roles": ["guest"], "password": "secret"}`
+
+	violations, err := validator.Validate(req, &definition.OpenAPI{Spec: "users.yaml", Operation: "PUT /users/{userId}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"path.userId: should be integer",
+		"query.notify: should be boolean",
+		"header.X-Request-Id: is required",
+		"body.email: is required",
+		"body.age: should be integer",
+		"body.name: should be at least 2 characters long",
+		"body.password: is not allowed",
+		`body.roles[0]: should be one of ["user","admin"]`,
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Error("Unexpected violations", violations)
+	}
+}
+
+func TestValidator_OperationNotFound(t *testing.T) {
+	validator, cleanup := getValidator(t)
+	defer cleanup()
+
+	req := &definition.Request{Method: "GET", Path: "/v1/users/12"}
+	if _, err := validator.Validate(req, &definition.OpenAPI{Spec: "users.yaml", Operation: "getUser"}); err != ErrOperationNotFound {
+		t.Error("The missing operation should be reported", err)
+	}
+}
+
+func TestValidateSchema_OneOfAndAnyOf(t *testing.T) {
+	doc, err := ParseDocument([]byte(`{"openapi": "3.0.0", "paths": {}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := []interface{}{
+		map[string]interface{}{"type": "number"},
+		map[string]interface{}{"type": "integer"},
+		map[string]interface{}{"type": "string"},
+	}
+
+	if violations := doc.ValidateSchema(map[string]interface{}{"oneOf": schemas}, "id", "body"); len(violations) != 0 {
+		t.Error("The value matching a single schema should be valid", violations)
+	}
+	expected := []string{"body: should match exactly one of the schemas in oneOf, but matches 2"}
+	if violations := doc.ValidateSchema(map[string]interface{}{"oneOf": schemas}, 1.0, "body"); !reflect.DeepEqual(violations, expected) {
+		t.Error("The value matching several schemas should not be valid for oneOf", violations)
+	}
+	if violations := doc.ValidateSchema(map[string]interface{}{"anyOf": schemas}, 1.0, "body"); len(violations) != 0 {
+		t.Error("The value matching several schemas should be valid for anyOf", violations)
+	}
+	expected = []string{"body: should match one of the schemas in oneOf"}
+	if violations := doc.ValidateSchema(map[string]interface{}{"oneOf": schemas}, true, "body"); !reflect.DeepEqual(violations, expected) {
+		t.Error("The value matching no schema should not be valid", violations)
+	}
+}
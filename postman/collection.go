@@ -0,0 +1,92 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//Collection is a Postman v2.1 collection, see https://schema.getpostman.com/json/collection/v2.1.0/collection.json
+type Collection struct {
+	Info     Info       `json:"info"`
+	Items    []Item     `json:"item"`
+	Variable []Variable `json:"variable"`
+}
+
+//Info contains the collection name and schema.
+type Info struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+//Item is either a folder containing other items or a request with its saved example responses.
+type Item struct {
+	Name      string     `json:"name"`
+	Items     []Item     `json:"item"`
+	Request   *Request   `json:"request"`
+	Responses []Response `json:"response"`
+}
+
+//Request is a Postman request.
+type Request struct {
+	Method string     `json:"method"`
+	URL    URL        `json:"url"`
+	Header []KeyValue `json:"header"`
+	Body   *Body      `json:"body"`
+}
+
+//URL is the request URL, it can be defined as string or as object.
+type URL struct {
+	Raw      string     `json:"raw"`
+	Path     []string   `json:"path"`
+	Query    []KeyValue `json:"query"`
+	Variable []Variable `json:"variable"`
+}
+
+//UnmarshalJSON allows defining the URL as raw string.
+func (u *URL) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &u.Raw)
+	}
+	type plainURL URL
+	return json.Unmarshal(data, (*plainURL)(u))
+}
+
+//Body is the request body.
+type Body struct {
+	Mode       string     `json:"mode"`
+	Raw        string     `json:"raw"`
+	URLEncoded []KeyValue `json:"urlencoded"`
+}
+
+//Response is a saved example response together with the request it was received for.
+type Response struct {
+	Name            string     `json:"name"`
+	OriginalRequest *Request   `json:"originalRequest"`
+	Code            int        `json:"code"`
+	Header          []KeyValue `json:"header"`
+	Body            string     `json:"body"`
+}
+
+//KeyValue is a header, query string or form parameter.
+type KeyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+//Variable is a collection or a path variable, their values can be of any type.
+type Variable struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+//String returns the variable value as string.
+func (v Variable) String() string {
+	if v.Value == nil {
+		return ""
+	}
+	if value, ok := v.Value.(string); ok {
+		return value
+	}
+	return fmt.Sprint(v.Value)
+}
@@ -0,0 +1,264 @@
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//ResponseNameHeader is the request header used for selecting an example response different from the first one,
+//the same header is used by the Postman mock servers
+const ResponseNameHeader = "X-Mock-Response-Name"
+
+var (
+	variableRegex = regexp.MustCompile(`\{\{\s*([^{}\s]+)\s*\}\}`)
+	hostRegex     = regexp.MustCompile(`^(\{\{[^}]*\}\}|[a-zA-Z][\w+.-]*://[^/?#]*|[^/?#]*\.[^/?#]*)`)
+	schemaRegex   = regexp.MustCompile(`getpostman\.com/json/collection/v2\.[01]`)
+)
+
+//Reader generates mock definitions from the saved examples of Postman v2.1 collections.
+type Reader struct {
+}
+
+//CanRead return true if the file has the schema of the Postman collections, the collection is not parsed
+func (r Reader) CanRead(filename string, content []byte) bool {
+	return filepath.Ext(filename) == ".json" && schemaRegex.Match(content)
+}
+
+//ReadMultiple returns a mock definition for every saved example response in the collection,
+//the requests without examples get an empty 200 response
+func (r Reader) ReadMultiple(filename string, content []byte) ([]definition.Mock, error) {
+	logging.Printf("Loading Postman config: %s\n", filename)
+	collection := Collection{}
+	if err := json.Unmarshal(content, &collection); err != nil {
+		return nil, err
+	}
+	return BuildMocks(collection), nil
+}
+
+//BuildMocks turns the collection requests and their examples into mock definitions.
+//The first example of a request is served by default, the others are selected by name with the X-Mock-Response-Name header.
+func BuildMocks(collection Collection) []definition.Mock {
+	variables := map[string]string{}
+	for _, variable := range collection.Variable {
+		variables[variable.Key] = variable.String()
+	}
+	b := builder{variables: variables, mocks: []definition.Mock{}, requests: map[string]bool{}, names: map[string]bool{}}
+	b.addItems(collection.Items, "")
+	return b.mocks
+}
+
+type builder struct {
+	variables map[string]string
+	mocks     []definition.Mock
+	//requests contains the requests having a default mock already
+	requests map[string]bool
+	//names contains the mock names already used, as the items and the examples of a folder can have the same name
+	names map[string]bool
+}
+
+func (b *builder) addItems(items []Item, folder string) {
+	for _, item := range items {
+		name := strings.Trim(folder+"/"+item.Name, "/")
+		if item.Request == nil {
+			b.addItems(item.Items, name)
+			continue
+		}
+		if len(item.Responses) == 0 {
+			b.addMock(name, item.Name, *item.Request, definition.Response{StatusCode: http.StatusOK})
+			continue
+		}
+		for _, example := range item.Responses {
+			request := item.Request
+			if example.OriginalRequest != nil {
+				request = example.OriginalRequest
+			}
+			b.addMock(name+"/"+example.Name, example.Name, *request, b.buildResponse(example))
+		}
+	}
+}
+
+func (b *builder) addMock(name string, responseName string, request Request, response definition.Response) {
+	mock := definition.Mock{Description: name, Response: response}
+	mock.Name = b.uniqueName(strings.ToLower(utils.CleanName(name)))
+	mock.Request = b.buildRequest(request)
+
+	key := fmt.Sprintf("%s %s?%v\n%s", mock.Request.Method, mock.Request.Path, mock.Request.QueryStringParameters, mock.Request.Body)
+	if b.requests[key] {
+		mock.Request.HeaderMatchers = definition.ValueMatchers{ResponseNameHeader: {EqualTo: responseName}}
+		mock.Control.Priority = 1
+	}
+	b.requests[key] = true
+	b.mocks = append(b.mocks, mock)
+}
+
+//uniqueName adds an index suffix to the names used by the previous mocks
+func (b *builder) uniqueName(name string) string {
+	unique := name
+	for i := 2; b.names[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	b.names[unique] = true
+	return unique
+}
+
+func (b *builder) buildRequest(request Request) definition.Request {
+	res := definition.Request{Method: strings.ToUpper(request.Method)}
+	if res.Method == "" {
+		res.Method = http.MethodGet
+	}
+
+	// the Postman path variables like :id are already route parameters
+	res.Path = b.buildPath(request.URL)
+
+	query := request.URL.Query
+	if len(query) == 0 {
+		query = parseRawQuery(request.URL.Raw)
+	}
+	for _, param := range query {
+		if param.Disabled {
+			continue
+		}
+		if res.QueryStringParameters == nil {
+			res.QueryStringParameters = make(definition.Values)
+		}
+		// the parameters with unresolved variables are matched by name only
+		if value, resolved := b.resolve(param.Value); resolved {
+			res.QueryStringParameters[param.Key] = append(res.QueryStringParameters[param.Key], value)
+		} else {
+			if res.QueryStringMatchers == nil {
+				res.QueryStringMatchers = make(definition.ValueMatchers)
+			}
+			res.QueryStringMatchers[param.Key] = definition.ValueMatcher{}
+		}
+	}
+	if len(res.QueryStringParameters) == 0 {
+		res.QueryStringParameters = nil
+	}
+
+	for _, header := range request.Header {
+		value, resolved := b.resolve(header.Value)
+		if header.Disabled || !resolved {
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = make(definition.Values)
+		}
+		name := http.CanonicalHeaderKey(header.Key)
+		res.Headers[name] = append(res.Headers[name], value)
+	}
+
+	if request.Body != nil {
+		switch request.Body.Mode {
+		case "raw":
+			if body, resolved := b.resolve(request.Body.Raw); resolved {
+				res.Body = body
+			}
+		case "urlencoded":
+			form := url.Values{}
+			for _, param := range request.Body.URLEncoded {
+				if !param.Disabled {
+					form.Add(param.Key, param.Value)
+				}
+			}
+			res.Body = form.Encode()
+		}
+	}
+	return res
+}
+
+//buildPath returns the URL path with the collection variables resolved,
+//the unresolved ones become named route parameters
+func (b *builder) buildPath(u URL) string {
+	segments := u.Path
+	if len(segments) == 0 {
+		raw := hostRegex.ReplaceAllString(u.Raw, "")
+		if i := strings.IndexAny(raw, "?#"); i >= 0 {
+			raw = raw[:i]
+		}
+		segments = strings.Split(strings.Trim(raw, "/"), "/")
+	}
+	path := ""
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		segment = variableRegex.ReplaceAllStringFunc(segment, func(raw string) string {
+			name := variableRegex.FindStringSubmatch(raw)[1]
+			if value, ok := b.variables[name]; ok {
+				return value
+			}
+			return ":" + name
+		})
+		path += "/" + segment
+	}
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func (b *builder) buildResponse(example Response) definition.Response {
+	res := definition.Response{StatusCode: example.Code, Body: example.Body}
+	if res.StatusCode == 0 {
+		res.StatusCode = http.StatusOK
+	}
+	for _, header := range example.Header {
+		if header.Disabled || utils.IsHeaderIgnored(utils.IgnoredResponseHeaders, header.Key) {
+			continue
+		}
+		if res.Headers == nil {
+			res.Headers = make(definition.Values)
+		}
+		name := http.CanonicalHeaderKey(header.Key)
+		res.Headers[name] = append(res.Headers[name], header.Value)
+	}
+	return res
+}
+
+//resolve replaces the collection variables, it returns false if some of them are not defined
+func (b *builder) resolve(value string) (string, bool) {
+	resolved := true
+	value = variableRegex.ReplaceAllStringFunc(value, func(raw string) string {
+		name := variableRegex.FindStringSubmatch(raw)[1]
+		if variable, ok := b.variables[name]; ok {
+			return variable
+		}
+		resolved = false
+		return raw
+	})
+	return value, resolved
+}
+
+func parseRawQuery(raw string) []KeyValue {
+	params := []KeyValue{}
+	i := strings.Index(raw, "?")
+	if i < 0 {
+		return params
+	}
+	query := raw[i+1:]
+	if j := strings.Index(query, "#"); j >= 0 {
+		query = query[:j]
+	}
+	for _, pair := range strings.Split(query, "&") {
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		key, _ := url.QueryUnescape(parts[0])
+		value := ""
+		if len(parts) > 1 {
+			value, _ = url.QueryUnescape(parts[1])
+		}
+		params = append(params, KeyValue{Key: key, Value: value})
+	}
+	return params
+}
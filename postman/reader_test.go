@@ -0,0 +1,166 @@
+package postman
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+const postmanCollection = `{
+	"info": {"name": "Test", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+	"variable": [{"key": "baseUrl", "value": "http://localhost:8080"}, {"key": "version", "value": 2}],
+	"item": [
+		{
+			"name": "Orders",
+			"item": [
+				{
+					"name": "Get order",
+					"request": {
+						"method": "GET",
+						"header": [
+							{"key": "accept", "value": "application/json"},
+							{"key": "Authorization", "value": "Bearer {{token}}"},
+							{"key": "X-Debug", "value": "1", "disabled": true}
+						],
+						"url": {
+							"raw": "{{baseUrl}}/v{{version}}/orders/:id?expand=items",
+							"path": ["v{{version}}", "orders", ":id"],
+							"query": [{"key": "expand", "value": "items"}, {"key": "trace", "value": "1", "disabled": true}]
+						}
+					},
+					"response": [
+						{
+							"name": "Order found",
+							"code": 200,
+							"header": [{"key": "Content-Type", "value": "application/json"}, {"key": "Content-Length", "value": "8"}],
+							"body": "{\"id\":1}"
+						},
+						{
+							"name": "Order missing",
+							"code": 404,
+							"body": "not found"
+						}
+					]
+				}
+			]
+		},
+		{
+			"name": "Login",
+			"request": {
+				"method": "POST",
+				"url": "{{baseUrl}}/login/{{tenant}}",
+				"body": {"mode": "urlencoded", "urlencoded": [{"key": "user", "value": "john"}, {"key": "debug", "value": "1", "disabled": true}]}
+			}
+		}
+	]
+}`
+
+func TestBuildMocks(t *testing.T) {
+	collection := Collection{}
+	if err := json.Unmarshal([]byte(postmanCollection), &collection); err != nil {
+		t.Fatal(err)
+	}
+	mocks := BuildMocks(collection)
+	if len(mocks) != 3 {
+		t.Fatal("A mock should be created for every example and request without examples", len(mocks))
+	}
+
+	found := mocks[0]
+	if found.Name != "orders-get-order-order-found" || found.Request.Method != "GET" || found.Request.Path != "/v2/orders/:id" {
+		t.Error("Unexpected mock request", found.Name, found.Request.Method, found.Request.Path)
+	}
+	if len(found.Request.QueryStringParameters) != 1 || found.Request.QueryStringParameters["expand"][0] != "items" {
+		t.Error("Only the enabled query parameters should be matched", found.Request.QueryStringParameters)
+	}
+	if len(found.Request.Headers) != 1 || found.Request.Headers["Accept"][0] != "application/json" {
+		t.Error("The disabled headers and the ones with unresolved variables should not be matched", found.Request.Headers)
+	}
+	if found.Response.StatusCode != 200 || found.Response.Body != `{"id":1}` || len(found.Response.Headers) != 1 {
+		t.Error("Unexpected mock response", found.Response)
+	}
+	if len(found.Request.HeaderMatchers) != 0 || found.Control.Priority != 0 {
+		t.Error("The first example should be served by default")
+	}
+
+	missing := mocks[1]
+	if missing.Response.StatusCode != 404 || missing.Control.Priority != 1 {
+		t.Error("The other examples should have higher priority", missing.Response.StatusCode, missing.Control.Priority)
+	}
+	if matcher, ok := missing.Request.HeaderMatchers[ResponseNameHeader]; !ok || matcher.EqualTo != "Order missing" {
+		t.Error("The other examples should be selected by name", missing.Request.HeaderMatchers)
+	}
+
+	login := mocks[2]
+	if login.Request.Method != "POST" || login.Request.Path != "/login/:tenant" || login.Request.Body != "user=john" {
+		t.Error("Unexpected request without examples", login.Request)
+	}
+	if login.Response.StatusCode != 200 || login.Response.Body != "" {
+		t.Error("The requests without examples should get an empty response", login.Response)
+	}
+}
+
+func TestBuildMocks_DuplicateNames(t *testing.T) {
+	request := &Request{Method: "GET", URL: URL{Raw: "https://api.example.com/orders"}}
+	examples := []Response{{Name: "Found", Code: 200}, {Name: "Found", Code: 200}}
+	collection := Collection{Items: []Item{
+		{Name: "Orders", Items: []Item{
+			{Name: "List", Request: request, Responses: examples},
+			{Name: "List", Request: request, Responses: examples},
+		}},
+	}}
+
+	names := map[string]bool{}
+	for _, mock := range BuildMocks(collection) {
+		if names[mock.Name] {
+			t.Error("The mock names should be unique", mock.Name)
+		}
+		names[mock.Name] = true
+	}
+	if len(names) != 4 || !names["orders-list-found"] || !names["orders-list-found-2"] {
+		t.Error("The duplicate names should get an index suffix", names)
+	}
+}
+
+const collectionFile = "../config/postman/users.postman_collection.json"
+
+func TestCanRead(t *testing.T) {
+	reader := Reader{}
+	content, _ := ioutil.ReadFile(collectionFile)
+	if !reader.CanRead(collectionFile, content) {
+		t.Error("The Postman collections should be read")
+	}
+	content, _ = ioutil.ReadFile("../config/hello.json")
+	if reader.CanRead("../config/hello.json", content) {
+		t.Error("The mock definitions should not be read as Postman collections")
+	}
+}
+
+func TestReadMultiple(t *testing.T) {
+	content, err := ioutil.ReadFile(collectionFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mocks, err := Reader{}.ReadMultiple(collectionFile, content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := []string{}
+	for _, mock := range mocks {
+		paths = append(paths, mock.Request.Method+" "+mock.Request.Path)
+	}
+	expected := []string{"GET /postman/v1/users/:id", "GET /postman/v1/users/:id", "POST /postman/v1/users", "GET /postman/health"}
+	if len(paths) != len(expected) {
+		t.Fatal("Unexpected mocks", paths)
+	}
+	for i := range expected {
+		if paths[i] != expected[i] {
+			t.Error("Unexpected mock", i, paths[i])
+		}
+	}
+	if mocks[3].Request.QueryStringParameters["verbose"][0] != "true" {
+		t.Error("The raw URL query should be matched", mocks[3].Request.QueryStringParameters)
+	}
+	var _ definition.MultipleConfigReader = Reader{}
+}
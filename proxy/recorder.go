@@ -0,0 +1,216 @@
+package proxy
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/route"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//NewRecorder returns a pointer to a new Recorder writing the mock definitions in the given path.
+func NewRecorder(path string, format string, stripHeaders []string, deduplicate bool) *Recorder {
+	if format != "yaml" {
+		format = "json"
+	}
+	return &Recorder{
+		Path:         path,
+		Format:       format,
+		StripHeaders: stripHeaders,
+		Deduplicate:  deduplicate,
+	}
+}
+
+//FileWatcher reloads the mocks when the files change, the recorded files are skipped as the recorder adds their mocks itself
+type FileWatcher interface {
+	IgnoreFile(filename string)
+}
+
+//Recorder writes the proxied requests and the responses received from the real service as mock definitions.
+type Recorder struct {
+	Enabled      bool     // record all the proxied requests, not only the ones of mocks with record control
+	Path         string   // folder where the mock definitions are written
+	Format       string   // json or yaml
+	StripHeaders []string // response headers which are not recorded e.g. Date
+	Deduplicate  bool     // don't record the same request twice
+	//Mocks gets the recorded mocks, so they are replayed without reloading the config path
+	Mocks route.MockManager
+	//Watcher skips the changes of the recorded files, so they don't reload the config path
+	Watcher FileWatcher
+	sync.Mutex
+}
+
+//Record writes a new mock definition for the request and response, it returns the name of the created file.
+//The recorded mock has higher priority than the proxy one, so it replays the response from now on.
+func (rec *Recorder) Record(request definition.Request, response definition.Response, priority int) (string, error) {
+	rec.Lock()
+	defer rec.Unlock()
+
+	fileName := rec.getFileName(request)
+	if rec.Deduplicate {
+		if _, err := os.Stat(fileName); err == nil {
+			logging.Printf("Request already recorded in: %s\n", fileName)
+			return fileName, nil
+		}
+	}
+
+	mock := definition.Mock{}
+	mock.Description = fmt.Sprintf("Recorded at %s", time.Now().Format(time.RFC3339))
+	mock.Request.Method = request.Method
+	mock.Request.Path = request.Path
+	mock.Request.QueryStringParameters = request.QueryStringParameters
+	// the JSON bodies are matched as JSON, as the glob stars in the body would match other requests as well
+	if isJSONBody(request.Body) {
+		mock.Request.BodyJSON = json.RawMessage(request.Body)
+	} else {
+		mock.Request.Body = request.Body
+	}
+	mock.Response = rec.stripHeaders(response)
+	mock.Control.Priority = priority + 1
+
+	content, err := rec.serialize(mock)
+	if err != nil {
+		return "", err
+	}
+	if rec.Watcher != nil {
+		if _, err := os.Stat(rec.Path); os.IsNotExist(err) {
+			rec.Watcher.IgnoreFile(rec.Path)
+		}
+		rec.Watcher.IgnoreFile(fileName)
+	}
+	if err := os.MkdirAll(rec.Path, 0755); err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(fileName, content, 0644); err != nil {
+		return "", err
+	}
+	logging.Printf("Request recorded in: %s\n", fileName)
+	if rec.Mocks != nil {
+		mock.Name = filepath.Base(fileName)
+		if err := rec.Mocks.AddMockDefinition(mock); err != nil {
+			logging.Printf("Error replaying the recorded request: %s\n", err.Error())
+		}
+	}
+	return fileName, nil
+}
+
+//isJSONBody checks whether the body is a JSON object or array
+func isJSONBody(body string) bool {
+	body = strings.TrimSpace(body)
+	return (strings.HasPrefix(body, "{") || strings.HasPrefix(body, "[")) && json.Valid([]byte(body))
+}
+
+func (rec *Recorder) stripHeaders(response definition.Response) definition.Response {
+	headers := make(definition.Values)
+	for header, values := range response.Headers {
+		if !rec.isStripped(header) {
+			headers[header] = values
+		}
+	}
+	response.Headers = headers
+	return response
+}
+
+func (rec *Recorder) isStripped(header string) bool {
+	for _, stripped := range rec.StripHeaders {
+		if strings.EqualFold(strings.TrimSpace(stripped), header) {
+			return true
+		}
+	}
+	return false
+}
+
+//getFileName returns the file name for the request, identical requests get the same name when deduplicating.
+func (rec *Recorder) getFileName(request definition.Request) string {
+	name := utils.CleanName(request.Path)
+	if name == "" {
+		name = "root"
+	}
+	name = fmt.Sprintf("%s-%s-%s", strings.ToLower(request.Method), name, rec.getRequestHash(request))
+	if !rec.Deduplicate {
+		name = fmt.Sprintf("%s-%d", name, time.Now().UnixNano())
+	}
+	return filepath.Join(rec.Path, name+"."+rec.Format)
+}
+
+func (rec *Recorder) getRequestHash(request definition.Request) string {
+	keys := []string{}
+	for key := range request.QueryStringParameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha1.New()
+	fmt.Fprintf(hash, "%s %s\n", request.Method, request.Path)
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s=%s\n", key, strings.Join(request.QueryStringParameters[key], ","))
+	}
+	fmt.Fprint(hash, request.Body)
+	return hex.EncodeToString(hash.Sum(nil))[:8]
+}
+
+//serialize writes the mock definition without the empty sections.
+func (rec *Recorder) serialize(mock definition.Mock) ([]byte, error) {
+	content, err := json.Marshal(mock)
+	if err != nil {
+		return nil, err
+	}
+	var data interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, err
+	}
+	data = removeEmptyValues(data)
+
+	if rec.Format == "yaml" {
+		return yaml.Marshal(data)
+	}
+	return json.MarshalIndent(data, "", "\t")
+}
+
+func removeEmptyValues(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if item = removeEmptyValues(item); isEmpty(item) {
+				delete(value, key)
+			} else {
+				value[key] = item
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = removeEmptyValues(item)
+		}
+	}
+	return data
+}
+
+func isEmpty(data interface{}) bool {
+	switch value := data.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case float64:
+		return value == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
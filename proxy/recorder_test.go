@@ -0,0 +1,167 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/match"
+	"github.com/vtrifonov/http-api-mock/route"
+)
+
+func getRecordedExchange() (definition.Request, definition.Response) {
+	request := definition.Request{Method: "GET", Path: "/users/1"}
+	request.QueryStringParameters = definition.Values{"expand": []string{"orders"}}
+
+	response := definition.Response{StatusCode: 200, Body: "{\"id\": 1}"}
+	response.Headers = definition.Values{
+		"Content-Type": []string{"application/json"},
+		"Date":         []string{"Mon, 01 Jan 2018 00:00:00 GMT"},
+	}
+	return request, response
+}
+
+func TestRecorder_RecordJSON(t *testing.T) {
+	path, _ := ioutil.TempDir("", "recordings")
+	defer os.RemoveAll(path)
+
+	recorder := NewRecorder(path, "json", []string{"Date"}, true)
+	request, response := getRecordedExchange()
+
+	fileName, err := recorder.Record(request, response, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock, err := definition.JSONReader{}.Read(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mock.Request.Path != "/users/1" || mock.Request.QueryStringParameters["expand"][0] != "orders" {
+		t.Error("The request should be recorded", mock.Request)
+	}
+	if mock.Response.Body != response.Body || mock.Response.StatusCode != 200 {
+		t.Error("The response should be recorded", mock.Response)
+	}
+	if _, found := mock.Response.Headers["Date"]; found {
+		t.Error("The Date header should be stripped")
+	}
+	if mock.Control.Priority != 1 {
+		t.Error("The recorded mock should have higher priority than the proxy one", mock.Control.Priority)
+	}
+
+	content, _ := ioutil.ReadFile(fileName)
+	if strings.Contains(string(content), "persist") {
+		t.Error("Empty sections should not be recorded", string(content))
+	}
+}
+
+func TestRecorder_Deduplicate(t *testing.T) {
+	path, _ := ioutil.TempDir("", "recordings")
+	defer os.RemoveAll(path)
+
+	request, response := getRecordedExchange()
+
+	recorder := NewRecorder(path, "yaml", []string{}, true)
+	first, _ := recorder.Record(request, response, 0)
+	second, _ := recorder.Record(request, response, 0)
+	if first != second || !strings.HasSuffix(first, ".yaml") {
+		t.Error("Identical requests should be recorded once", first, second)
+	}
+
+	recorder.Deduplicate = false
+	third, _ := recorder.Record(request, response, 0)
+	if third == first {
+		t.Error("Identical requests should be recorded again when not deduplicating", third)
+	}
+
+	files, _ := ioutil.ReadDir(path)
+	if len(files) != 2 {
+		t.Error("Two recordings expected", len(files))
+	}
+}
+
+func TestRecorder_AddsRecordedMock(t *testing.T) {
+	path, _ := ioutil.TempDir("", "recordings")
+	defer os.RemoveAll(path)
+
+	router := route.NewRouter(nil, match.MockMatch{}, nil)
+	recorder := NewRecorder(path, "json", []string{}, true)
+	recorder.Mocks = router
+	request, response := getRecordedExchange()
+
+	fileName, err := recorder.Record(request, response, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder.Record(request, response, 0)
+
+	mocks := router.GetMockDefinitions()
+	if len(mocks) != 1 || mocks[0].Name != filepath.Base(fileName) || mocks[0].Response.Body != response.Body {
+		t.Error("The recorded mock should be added once for replaying", mocks)
+	}
+}
+
+//ignoredFiles collects the files skipped by the watcher
+type ignoredFiles map[string]bool
+
+func (files ignoredFiles) IgnoreFile(filename string) {
+	files[filename] = true
+}
+
+func TestRecorder_IgnoresRecordedFiles(t *testing.T) {
+	path, _ := ioutil.TempDir("", "recordings")
+	defer os.RemoveAll(path)
+
+	ignored := ignoredFiles{}
+	recorder := NewRecorder(filepath.Join(path, "recorded"), "json", []string{}, true)
+	recorder.Watcher = ignored
+	request, response := getRecordedExchange()
+
+	fileName, err := recorder.Record(request, response, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ignored[fileName] || !ignored[recorder.Path] {
+		t.Error("The recorded file and the created folder should not reload the mocks", ignored)
+	}
+}
+
+func TestRecorder_RecordBody(t *testing.T) {
+	path, _ := ioutil.TempDir("", "recordings")
+	defer os.RemoveAll(path)
+
+	router := route.NewRouter(nil, match.MockMatch{}, nil)
+	recorder := NewRecorder(path, "json", []string{}, true)
+	recorder.Mocks = router
+	response := definition.Response{StatusCode: 200}
+
+	jsonRequest := definition.Request{Method: "POST", Path: "/search", Body: `{"query": "a*"}`}
+	textRequest := definition.Request{Method: "POST", Path: "/notes", Body: "plain text"}
+	for _, request := range []definition.Request{jsonRequest, textRequest} {
+		if _, err := recorder.Record(request, response, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, mock := range router.GetMockDefinitions() {
+		if mock.Request.Path == "/search" && (mock.Request.Body != "" || string(mock.Request.BodyJSON) != jsonRequest.Body) {
+			t.Error("The JSON body should be recorded as JSON matcher", mock.Request.Body, string(mock.Request.BodyJSON))
+		}
+		if mock.Request.Path == "/notes" && mock.Request.Body != textRequest.Body {
+			t.Error("The other bodies should be recorded as they are", mock.Request.Body)
+		}
+	}
+
+	other := definition.Request{Method: "POST", Path: "/search", Body: `{"query": "abc"}`}
+	if _, errs := router.Route(&other); errs == nil {
+		t.Error("The recorded JSON body should not match other requests")
+	}
+	same := definition.Request{Method: "POST", Path: "/search", Body: `{"query":"a*"}`}
+	if _, errs := router.Route(&same); errs != nil {
+		t.Error("The recorded JSON body should match the same request", errs)
+	}
+}
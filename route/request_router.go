@@ -3,27 +3,50 @@ package route
 import (
 	"bytes"
 	"encoding/gob"
+	"errors"
+	"math"
+	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/vtrifonov/http-api-mock/definition"
 	"github.com/vtrifonov/http-api-mock/logging"
 	"github.com/vtrifonov/http-api-mock/match"
+	"github.com/vtrifonov/http-api-mock/scenario"
+)
+
+var (
+	//ErrMockNotFound when there is no mock definition with the requested name
+	ErrMockNotFound = errors.New("Mock definition not found")
+	//ErrMockAlreadyExists when trying to add a mock definition with a name which is already in use
+	ErrMockAlreadyExists = errors.New("Mock definition already exists")
+	//ErrFileMock when trying to delete a mock definition loaded from the config path
+	ErrFileMock = errors.New("Mock definition is loaded from the config path and can't be deleted at runtime")
+	//ErrScenarioStateNotMatch when the mock scenario is not in the required state
+	ErrScenarioStateNotMatch = errors.New("Scenario state not match")
 )
 
 //NewRouter returns a pointer to new RequestRouter
 func NewRouter(mocks []definition.Mock, matcher match.Matcher, dUpdates chan []definition.Mock) *RequestRouter {
 	return &RequestRouter{
-		Mocks:    mocks,
-		Matcher:  match.MockMatch{},
-		DUpdates: dUpdates,
+		Mocks:     mocks,
+		Matcher:   match.MockMatch{},
+		DUpdates:  dUpdates,
+		Scenarios: scenario.NewStore(),
+		fileMocks: mocks,
+		calls:     make(map[string]int),
 	}
 }
 
 //RequestRouter checks http requesta and try to figure out what is the best mock for each one.
 type RequestRouter struct {
-	Mocks    []definition.Mock
-	Matcher  match.Matcher
-	DUpdates chan []definition.Mock
+	Mocks        []definition.Mock
+	Matcher      match.Matcher
+	DUpdates     chan []definition.Mock
+	Scenarios    *scenario.Store
+	fileMocks    []definition.Mock
+	runtimeMocks []definition.Mock
+	calls        map[string]int
 	sync.Mutex
 }
 
@@ -44,16 +67,33 @@ func (rr *RequestRouter) Copy(src, dst *definition.Mock) {
 
 //Route checks the request with all available mock definitions and return the matching mock for it.
 func (rr *RequestRouter) Route(req *definition.Request) (*definition.Mock, map[string]string) {
+	mock, errors, _ := rr.RouteValidated(req, nil)
+	return mock, errors
+}
+
+//RouteValidated returns the matching mock like Route, but the validator checks the request against the mock first.
+//The requests with violations don't change the scenario state and the response sequence of the mock.
+func (rr *RequestRouter) RouteValidated(req *definition.Request, validator Validator) (*definition.Mock, map[string]string, []string) {
 	errors := make(map[string]string)
 	rr.Lock()
 	defer rr.Unlock()
 	for _, mock := range rr.Mocks {
 		m, err := rr.Matcher.Match(req, &mock.Request)
+		if m && !rr.matchScenarioState(&mock) {
+			m, err = false, ErrScenarioStateNotMatch
+		}
 		if m {
 			//we return a copy of it, not the definition itself because we will working on it.
 			md := definition.Mock{}
 			rr.Copy(&mock, &md)
-			return &md, nil
+			if validator != nil {
+				if violations := validator(req, &md); len(violations) > 0 {
+					return &md, nil, violations
+				}
+			}
+			rr.applyScenarioTransition(&mock)
+			rr.selectSequenceResponse(&md)
+			return &md, nil, nil
 		}
 		errors[mock.Name] = err.Error()
 		if err != match.ErrPathNotMatch {
@@ -61,17 +101,201 @@ func (rr *RequestRouter) Route(req *definition.Request) (*definition.Mock, map[s
 		}
 	}
 
-	return &definition.Mock{Response: definition.Response{StatusCode: 404}}, errors
+	return &definition.Mock{Response: definition.Response{StatusCode: 404}}, errors, nil
+
+}
+
+//NearMisses returns the mock definitions closest to match the request, sorted by score.
+//Only the mocks with at least one of their checks passed are returned.
+func (rr *RequestRouter) NearMisses(req *definition.Request, limit int) []definition.NearMiss {
+	differ, ok := rr.Matcher.(match.Differ)
+	if !ok {
+		return nil
+	}
+	rr.Lock()
+	defer rr.Unlock()
+	nearMisses := []definition.NearMiss{}
+	for _, mock := range rr.Mocks {
+		checks, diffs := differ.Diff(req, &mock.Request)
+		if mock.Control.Scenario != "" && mock.Control.RequiredState != "" {
+			checks++
+			if state := rr.Scenarios.GetState(mock.Control.Scenario); state != mock.Control.RequiredState {
+				diffs = append(diffs, definition.FieldDiff{Field: "scenario." + mock.Control.Scenario, Expected: mock.Control.RequiredState, Actual: state})
+			}
+		}
+		if checks == 0 || len(diffs) == checks {
+			continue
+		}
+		score := float64(checks-len(diffs)) / float64(checks)
+		nearMisses = append(nearMisses, definition.NearMiss{MockName: mock.Name, Score: math.Floor(score*100) / 100, Diffs: diffs})
+	}
+	sort.Stable(definition.NearMissSort(nearMisses))
+	if limit > 0 && len(nearMisses) > limit {
+		nearMisses = nearMisses[:limit]
+	}
+	return nearMisses
+}
+
+func (rr *RequestRouter) matchScenarioState(mock *definition.Mock) bool {
+	if mock.Control.Scenario == "" || mock.Control.RequiredState == "" {
+		return true
+	}
+	return rr.Scenarios.GetState(mock.Control.Scenario) == mock.Control.RequiredState
+}
+
+func (rr *RequestRouter) applyScenarioTransition(mock *definition.Mock) {
+	if mock.Control.Scenario != "" && mock.Control.NewState != "" {
+		rr.Scenarios.SetState(mock.Control.Scenario, mock.Control.NewState)
+		logging.Printf("Scenario %s moved to state: %s\n", mock.Control.Scenario, mock.Control.NewState)
+	}
+}
+
+//selectSequenceResponse sets the mock response to the next one from the mock responses, if there are any.
+//When all the responses are served the last one is repeated, unless the mock cycles through them.
+func (rr *RequestRouter) selectSequenceResponse(mock *definition.Mock) {
+	if len(mock.Responses) == 0 {
+		return
+	}
+	call := rr.calls[mock.Name]
+	rr.calls[mock.Name] = call + 1
 
+	index := call
+	if index >= len(mock.Responses) {
+		if mock.Control.CycleResponses {
+			index = call % len(mock.Responses)
+		} else {
+			index = len(mock.Responses) - 1
+		}
+	}
+	mock.Response = mock.Responses[index]
 }
 
 //SetMockDefinitions allows replace the current mock definitions for new ones.
 func (rr *RequestRouter) SetMockDefinitions(mocks []definition.Mock) {
 	rr.Lock()
-	rr.Mocks = mocks
+	rr.fileMocks = mocks
+	rr.mergeMockDefinitions()
 	rr.Unlock()
 }
 
+//GetMockDefinitions returns all the mock definitions sorted by priority.
+func (rr *RequestRouter) GetMockDefinitions() []definition.Mock {
+	rr.Lock()
+	defer rr.Unlock()
+	mocks := make([]definition.Mock, len(rr.Mocks))
+	copy(mocks, rr.Mocks)
+	return mocks
+}
+
+//GetMockDefinition returns the mock definition with the given name.
+func (rr *RequestRouter) GetMockDefinition(name string) (definition.Mock, bool) {
+	rr.Lock()
+	defer rr.Unlock()
+	for _, mock := range rr.Mocks {
+		if mock.Name == name {
+			return mock, true
+		}
+	}
+	return definition.Mock{}, false
+}
+
+//AddMockDefinition adds a new runtime mock definition. It fails if there is already a mock with the same name.
+func (rr *RequestRouter) AddMockDefinition(mock definition.Mock) error {
+	rr.Lock()
+	defer rr.Unlock()
+	for _, existing := range rr.Mocks {
+		if existing.Name == mock.Name {
+			return ErrMockAlreadyExists
+		}
+	}
+	rr.runtimeMocks = append(rr.runtimeMocks, mock)
+	rr.mergeMockDefinitions()
+	return nil
+}
+
+//UpdateMockDefinition replaces the runtime mock definition with the same name or adds it if it is missing.
+//Runtime mock definitions take precedence over the file ones with the same name.
+func (rr *RequestRouter) UpdateMockDefinition(mock definition.Mock) (created bool) {
+	rr.Lock()
+	defer rr.Unlock()
+	if i := rr.runtimeMockIndex(mock.Name); i >= 0 {
+		rr.runtimeMocks[i] = mock
+	} else {
+		rr.runtimeMocks = append(rr.runtimeMocks, mock)
+		created = true
+	}
+	rr.mergeMockDefinitions()
+	return created
+}
+
+//DeleteMockDefinition removes the runtime mock definition with the given name.
+func (rr *RequestRouter) DeleteMockDefinition(name string) error {
+	rr.Lock()
+	defer rr.Unlock()
+	i := rr.runtimeMockIndex(name)
+	if i < 0 {
+		for _, mock := range rr.fileMocks {
+			if mock.Name == name {
+				return ErrFileMock
+			}
+		}
+		return ErrMockNotFound
+	}
+	rr.runtimeMocks = append(rr.runtimeMocks[:i], rr.runtimeMocks[i+1:]...)
+	rr.mergeMockDefinitions()
+	return nil
+}
+
+func (rr *RequestRouter) runtimeMockIndex(name string) int {
+	for i, mock := range rr.runtimeMocks {
+		if mock.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+//mergeMockDefinitions builds the mock definitions used for routing from the runtime and the file ones.
+func (rr *RequestRouter) mergeMockDefinitions() {
+	mocks := make([]definition.Mock, 0, len(rr.runtimeMocks)+len(rr.fileMocks))
+	mocks = append(mocks, rr.runtimeMocks...)
+	for _, mock := range rr.fileMocks {
+		if rr.runtimeMockIndex(mock.Name) < 0 {
+			mocks = append(mocks, mock)
+		}
+	}
+	sort.Stable(definition.PrioritySort(mocks))
+	rr.dropChangedSequences(mocks)
+	rr.Mocks = mocks
+}
+
+//dropChangedSequences starts over the response sequences of the mocks which are removed or changed, the others keep their position
+func (rr *RequestRouter) dropChangedSequences(mocks []definition.Mock) {
+	current := make(map[string]*definition.Mock, len(mocks))
+	for i := range mocks {
+		current[mocks[i].Name] = &mocks[i]
+	}
+	for _, mock := range rr.Mocks {
+		if updated, found := current[mock.Name]; !found || !reflect.DeepEqual(mock, *updated) {
+			delete(rr.calls, mock.Name)
+		}
+	}
+}
+
+//ResetSequences starts over the response sequences of all the mocks.
+func (rr *RequestRouter) ResetSequences() {
+	rr.Lock()
+	defer rr.Unlock()
+	rr.calls = make(map[string]int)
+}
+
+//ResetSequence starts over the response sequence of the mock with the given name.
+func (rr *RequestRouter) ResetSequence(name string) {
+	rr.Lock()
+	defer rr.Unlock()
+	delete(rr.calls, name)
+}
+
 //MockChangeWatch monitors the mock configuration dir and loads again all the mocks it something change.
 func (rr *RequestRouter) MockChangeWatch() {
 	go func() {
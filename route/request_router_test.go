@@ -0,0 +1,248 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/match"
+	"github.com/vtrifonov/http-api-mock/scenario"
+)
+
+func getMock(name string, path string, priority int) definition.Mock {
+	mock := definition.Mock{Name: name}
+	mock.Request.Method = "GET"
+	mock.Request.Path = path
+	mock.Response.StatusCode = 200
+	mock.Response.Body = name
+	mock.Control.Priority = priority
+	return mock
+}
+
+func TestRequestRouter_RuntimeMockRespectsPriority(t *testing.T) {
+	router := NewRouter([]definition.Mock{getMock("file.json", "/users/*", 1)}, match.MockMatch{}, nil)
+
+	if err := router.AddMockDefinition(getMock("low", "/users/1", 0)); err != nil {
+		t.Error(err)
+	}
+	if err := router.AddMockDefinition(getMock("high", "/users/1", 2)); err != nil {
+		t.Error(err)
+	}
+
+	req := &definition.Request{Method: "GET", Path: "/users/1"}
+	if mock, _ := router.Route(req); mock.Name != "high" {
+		t.Error("The mock with the highest priority should be returned", mock.Name)
+	}
+
+	router.DeleteMockDefinition("high")
+	if mock, _ := router.Route(req); mock.Name != "file.json" {
+		t.Error("The file mock should be returned", mock.Name)
+	}
+}
+
+func TestRequestRouter_RuntimeMockSurvivesReload(t *testing.T) {
+	router := NewRouter([]definition.Mock{getMock("file.json", "/users", 0)}, match.MockMatch{}, nil)
+	router.AddMockDefinition(getMock("runtime", "/orders", 0))
+
+	router.SetMockDefinitions([]definition.Mock{getMock("other.json", "/users", 0)})
+
+	if mocks := router.GetMockDefinitions(); len(mocks) != 2 {
+		t.Error("Both the runtime and the reloaded file mock should be present", len(mocks))
+	}
+	if _, found := router.GetMockDefinition("runtime"); !found {
+		t.Error("The runtime mock should be kept after reload")
+	}
+}
+
+func TestRequestRouter_UpdateMockDefinition(t *testing.T) {
+	router := NewRouter([]definition.Mock{getMock("file.json", "/users", 0)}, match.MockMatch{}, nil)
+
+	if created := router.UpdateMockDefinition(getMock("file.json", "/orders", 0)); !created {
+		t.Error("The runtime mock should be created")
+	}
+	if mocks := router.GetMockDefinitions(); len(mocks) != 1 || mocks[0].Request.Path != "/orders" {
+		t.Error("The runtime mock should replace the file mock with the same name", mocks)
+	}
+	if created := router.UpdateMockDefinition(getMock("file.json", "/customers", 0)); created {
+		t.Error("The runtime mock should be replaced")
+	}
+	if err := router.DeleteMockDefinition("file.json"); err != nil {
+		t.Error(err)
+	}
+	if err := router.DeleteMockDefinition("file.json"); err != ErrFileMock {
+		t.Error("File mocks can't be deleted", err)
+	}
+	if err := router.DeleteMockDefinition("missing"); err != ErrMockNotFound {
+		t.Error("Missing mock should not be deleted", err)
+	}
+}
+
+func TestRequestRouter_ScenarioStates(t *testing.T) {
+	missing := getMock("missing", "/users/1", 0)
+	missing.Response.StatusCode = 404
+	missing.Control.Scenario = "user"
+	missing.Control.RequiredState = scenario.StartedState
+
+	create := getMock("create", "/users", 0)
+	create.Request.Method = "POST"
+	create.Control.Scenario = "user"
+	create.Control.NewState = "created"
+
+	existing := getMock("existing", "/users/1", 0)
+	existing.Control.Scenario = "user"
+	existing.Control.RequiredState = "created"
+
+	router := NewRouter([]definition.Mock{missing, create, existing}, match.MockMatch{}, nil)
+
+	get := &definition.Request{Method: "GET", Path: "/users/1"}
+	if mock, _ := router.Route(get); mock.Name != "missing" {
+		t.Error("The user should be missing before it is created", mock.Name)
+	}
+
+	router.Route(&definition.Request{Method: "POST", Path: "/users"})
+	if state := router.Scenarios.GetState("user"); state != "created" {
+		t.Error("The scenario should move to the created state", state)
+	}
+
+	if mock, _ := router.Route(get); mock.Name != "existing" {
+		t.Error("The user should exist after it is created", mock.Name)
+	}
+
+	router.Scenarios.Reset()
+	if mock, _ := router.Route(get); mock.Name != "missing" {
+		t.Error("The user should be missing after reset", mock.Name)
+	}
+}
+
+func getSequenceMock(cycle bool) definition.Mock {
+	mock := getMock("retry", "/orders", 0)
+	mock.Responses = []definition.Response{
+		definition.Response{StatusCode: 503},
+		definition.Response{StatusCode: 503},
+		definition.Response{StatusCode: 200},
+	}
+	mock.Control.CycleResponses = cycle
+	return mock
+}
+
+func TestRequestRouter_SequenceResponsesStopAtLast(t *testing.T) {
+	router := NewRouter([]definition.Mock{getSequenceMock(false)}, match.MockMatch{}, nil)
+	req := &definition.Request{Method: "GET", Path: "/orders"}
+
+	for i, expected := range []int{503, 503, 200, 200, 200} {
+		if mock, _ := router.Route(req); mock.Response.StatusCode != expected {
+			t.Error("Unexpected status code for call", i, mock.Response.StatusCode, expected)
+		}
+	}
+}
+
+func TestRequestRouter_SequenceResponsesCycle(t *testing.T) {
+	router := NewRouter([]definition.Mock{getSequenceMock(true)}, match.MockMatch{}, nil)
+	req := &definition.Request{Method: "GET", Path: "/orders"}
+
+	for i, expected := range []int{503, 503, 200, 503, 503, 200} {
+		if mock, _ := router.Route(req); mock.Response.StatusCode != expected {
+			t.Error("Unexpected status code for call", i, mock.Response.StatusCode, expected)
+		}
+	}
+
+}
+
+func TestRequestRouter_SequenceSurvivesUnrelatedChanges(t *testing.T) {
+	router := NewRouter([]definition.Mock{getSequenceMock(false)}, match.MockMatch{}, nil)
+	req := &definition.Request{Method: "GET", Path: "/orders"}
+	router.Route(req)
+	router.Route(req)
+
+	router.UpdateMockDefinition(getMock("users", "/users", 0))
+	router.SetMockDefinitions([]definition.Mock{getSequenceMock(false)})
+	if mock, _ := router.Route(req); mock.Response.StatusCode != 200 {
+		t.Error("The sequence should continue after unrelated changes", mock.Response.StatusCode)
+	}
+
+	changed := getSequenceMock(false)
+	changed.Responses[0].StatusCode = 500
+	router.SetMockDefinitions([]definition.Mock{changed})
+	if mock, _ := router.Route(req); mock.Response.StatusCode != 500 {
+		t.Error("The sequence should start over when the mock changes", mock.Response.StatusCode)
+	}
+
+	router.ResetSequence("retry")
+	if mock, _ := router.Route(req); mock.Response.StatusCode != 500 {
+		t.Error("The sequence should start over after reset", mock.Response.StatusCode)
+	}
+	router.ResetSequences()
+	if mock, _ := router.Route(req); mock.Response.StatusCode != 500 {
+		t.Error("The sequences should start over after reset", mock.Response.StatusCode)
+	}
+}
+
+func TestRequestRouter_InvalidRequestKeepsState(t *testing.T) {
+	mock := getSequenceMock(false)
+	mock.Request.Method = "POST"
+	mock.Control.Scenario = "order"
+	mock.Control.NewState = "created"
+	router := NewRouter([]definition.Mock{mock}, match.MockMatch{}, nil)
+
+	validator := func(req *definition.Request, mock *definition.Mock) []string {
+		if req.Body == "" {
+			return []string{"body: is required"}
+		}
+		return nil
+	}
+
+	invalid := &definition.Request{Method: "POST", Path: "/orders"}
+	for i := 0; i < 3; i++ {
+		if _, errs, violations := router.RouteValidated(invalid, validator); errs != nil || len(violations) != 1 {
+			t.Fatal("The invalid request should match with violations", errs, violations)
+		}
+	}
+	if state := router.Scenarios.GetState("order"); state != scenario.StartedState {
+		t.Error("The invalid requests should not change the scenario state", state)
+	}
+
+	valid := &definition.Request{Method: "POST", Path: "/orders", Body: "{}"}
+	if mock, _, violations := router.RouteValidated(valid, validator); len(violations) != 0 || mock.Response.StatusCode != 503 {
+		t.Error("The valid request should get the first response of the sequence", violations, mock.Response.StatusCode)
+	}
+	if state := router.Scenarios.GetState("order"); state != "created" {
+		t.Error("The valid request should change the scenario state", state)
+	}
+}
+
+func TestRequestRouter_NearMisses(t *testing.T) {
+	users := getMock("users", "/users/1", 0)
+	users.Request.Headers = definition.Values{"Authorization": []string{"Bearer token"}}
+	orders := getMock("orders", "/orders", 0)
+	orders.Request.Method = "POST"
+	router := NewRouter([]definition.Mock{users, orders}, match.MockMatch{}, nil)
+
+	req := &definition.Request{Method: "GET", Path: "/users/1"}
+	req.Headers = definition.Values{"Authorization": []string{"Bearer other"}}
+	if _, errs := router.Route(req); errs == nil {
+		t.Fatal("The request should not match")
+	}
+
+	nearMisses := router.NearMisses(req, 3)
+	if len(nearMisses) != 1 || nearMisses[0].MockName != "users" {
+		t.Fatal("Only the users mock should be a near miss", nearMisses)
+	}
+	if nearMisses[0].Score != 0.66 {
+		t.Error("Two of the three checks should pass", nearMisses[0].Score)
+	}
+	expected := definition.FieldDiff{Field: "headers.Authorization", Expected: "Bearer token", Actual: "Bearer other"}
+	if len(nearMisses[0].Diffs) != 1 || nearMisses[0].Diffs[0] != expected {
+		t.Error("The header diff should be reported", nearMisses[0].Diffs)
+	}
+}
+
+func TestRequestRouter_CopyKeepsZeroFaultProbability(t *testing.T) {
+	probability := 0.0
+	mock := getMock("faults", "/orders", 0)
+	mock.Control.Faults = []definition.Fault{{Type: definition.FaultTimeout, Probability: &probability}}
+	router := NewRouter([]definition.Mock{mock}, match.MockMatch{}, nil)
+
+	routed, _ := router.Route(&definition.Request{Method: "GET", Path: "/orders"})
+	if p := routed.Control.Faults[0].Probability; p == nil || *p != 0 {
+		t.Error("The zero probability should be kept", p)
+	}
+}
@@ -4,8 +4,24 @@ import (
 	"github.com/vtrifonov/http-api-mock/definition"
 )
 
+//Validator returns the violations of the request against the matched mock, they are checked before the mock changes any state.
+type Validator func(req *definition.Request, mock *definition.Mock) []string
+
 //Router contains the functions to check the http request and return the matching mock.
 type Router interface {
 	Route(req *definition.Request) (*definition.Mock, map[string]string)
+	RouteValidated(req *definition.Request, validator Validator) (*definition.Mock, map[string]string, []string)
 	SetMockDefinitions(mocks []definition.Mock)
+	NearMisses(req *definition.Request, limit int) []definition.NearMiss
+}
+
+//MockManager contains the functions to inspect and change the mock definitions at runtime.
+type MockManager interface {
+	GetMockDefinitions() []definition.Mock
+	GetMockDefinition(name string) (definition.Mock, bool)
+	AddMockDefinition(mock definition.Mock) error
+	UpdateMockDefinition(mock definition.Mock) (created bool)
+	DeleteMockDefinition(name string) error
+	ResetSequences()
+	ResetSequence(name string)
 }
@@ -0,0 +1,59 @@
+package scenario
+
+import "sync"
+
+//StartedState is the state of every scenario before its first transition
+const StartedState = "Started"
+
+//NewStore returns a pointer to a new empty Store.
+func NewStore() *Store {
+	return &Store{states: make(map[string]string)}
+}
+
+//Store keeps the current state of the named scenarios.
+type Store struct {
+	states map[string]string
+	sync.Mutex
+}
+
+//GetState returns the current state of the scenario or StartedState if it has never changed.
+func (s *Store) GetState(name string) string {
+	s.Lock()
+	defer s.Unlock()
+	if state, ok := s.states[name]; ok {
+		return state
+	}
+	return StartedState
+}
+
+//SetState changes the current state of the scenario.
+func (s *Store) SetState(name string, state string) {
+	s.Lock()
+	s.states[name] = state
+	s.Unlock()
+}
+
+//States returns the current state of all the scenarios which have changed.
+func (s *Store) States() map[string]string {
+	s.Lock()
+	defer s.Unlock()
+	states := make(map[string]string, len(s.states))
+	for name, state := range s.states {
+		states[name] = state
+	}
+	return states
+}
+
+//ResetScenario moves the scenario back to StartedState.
+func (s *Store) ResetScenario(name string) {
+	s.Lock()
+	delete(s.states, name)
+	s.Unlock()
+}
+
+//Reset moves all the scenarios back to StartedState.
+func (s *Store) Reset() {
+	s.Lock()
+	s.states = make(map[string]string)
+	s.Unlock()
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -10,11 +12,16 @@ import (
 	"reflect"
 
 	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/graphql"
+	"github.com/vtrifonov/http-api-mock/journal"
 	"github.com/vtrifonov/http-api-mock/logging"
 	"github.com/vtrifonov/http-api-mock/notify"
+	"github.com/vtrifonov/http-api-mock/openapi"
 	"github.com/vtrifonov/http-api-mock/proxy"
 	"github.com/vtrifonov/http-api-mock/route"
+	"github.com/vtrifonov/http-api-mock/soap"
 	"github.com/vtrifonov/http-api-mock/translate"
+	"github.com/vtrifonov/http-api-mock/utils"
 	"github.com/vtrifonov/http-api-mock/vars"
 )
 
@@ -22,12 +29,41 @@ import (
 type Dispatcher struct {
 	IP            string
 	Port          int
+	TLSPort       int
+	TLSConfig     *tls.Config
 	Router        route.Router
 	Translator    translate.MessageTranslator
 	VarsProcessor vars.VarsProcessor
 	Notifier      notify.Notifier
 	Mlog          chan definition.Match
 	Logs          chan string
+	Journal       *journal.Journal
+	Recorder      *proxy.Recorder
+	//NearMisses returns the closest mock definitions in the body of the not matched requests
+	NearMisses bool
+	//Validator checks the requests of the mocks linked to OpenAPI operations, nil disables the validation
+	Validator *openapi.Validator
+	//ValidationStatusCode is the status code of the requests not valid against the OpenAPI operation or the GraphQL schema
+	ValidationStatusCode int
+	//GraphQL loads the schemas of the GraphQL mocks, used for generating and validating their responses
+	GraphQL *graphql.Schemas
+	//ValidateGraphQL checks the requests of the GraphQL mocks having schema
+	ValidateGraphQL bool
+}
+
+//nearMissesLimit is the number of the closest mock definitions reported for a not matched request
+const nearMissesLimit = 3
+
+//validationErrorBody is the body of the requests not valid against the OpenAPI operation
+type validationErrorBody struct {
+	Message string   `json:"message"`
+	Errors  []string `json:"errors"`
+}
+
+//notFoundBody is the body of the not matched requests when the near misses are returned
+type notFoundBody struct {
+	Message    string                `json:"message"`
+	NearMisses []definition.NearMiss `json:"nearMisses"`
 }
 
 func (di Dispatcher) recordMatchData(msg definition.Match) {
@@ -36,7 +72,6 @@ func (di Dispatcher) recordMatchData(msg definition.Match) {
 
 func (di Dispatcher) randomStatusCode(currentStatus int) int {
 	if time.Now().Second()%2 == 0 {
-		rand.Seed(time.Now().Unix())
 		return rand.Intn(4) + 500
 	}
 	return currentStatus
@@ -54,23 +89,52 @@ func (di *Dispatcher) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 	logging.Printf("New request: %s %s\n", req.Method, req.URL.String())
 	result := definition.Result{}
-	mock, errs := di.Router.Route(&mRequest)
+	mock, errs, violations := di.Router.RouteValidated(&mRequest, di.validate)
 	if errs == nil {
 		result.Found = true
+		result.Violations = violations
 	} else {
 		result.Found = false
 		result.Errors = errs
+		result.NearMisses = di.Router.NearMisses(&mRequest, nearMissesLimit)
+		di.logNearMisses(result.NearMisses)
 	}
 
 	logging.Printf("Mock match found: %s. Name : %s\n", strconv.FormatBool(result.Found), mock.Name)
 
-	if result.Found {
+	if result.Found && len(result.Violations) > 0 && mock.Request.GraphQL != nil {
+		response = di.getGraphQLErrorResponse(result.Violations)
+	} else if result.Found && len(result.Violations) > 0 {
+		response = di.getValidationErrorResponse(result.Violations)
+	} else if result.Found && mock.WebSocket != nil {
+		di.VarsProcessor.Eval(&mRequest, mock)
+		if !reflect.DeepEqual(definition.Notify{}, mock.Notify) {
+			go di.Notifier.Notify(mock)
+		}
+		di.recordWebSocketMatch(mRequest, mock, result)
+		// the delay holds the handshake, the script delays are applied after the upgrade
+		delayResponse(mock.Control)
+		di.serveWebSocket(w, req, mRequest, mock)
+		return
+	} else if result.Found {
 		if len(mock.Control.ProxyBaseURL) > 0 {
 			pr := proxy.Proxy{URL: mock.Control.ProxyBaseURL}
-			response = pr.MakeRequest(mock.Request)
+			response = pr.MakeRequest(mRequest)
+			// the delay is added to the time of the proxied request
+			delayResponse(mock.Control)
+			if di.Recorder != nil && (di.Recorder.Enabled || mock.Control.Record) {
+				if _, err := di.Recorder.Record(mRequest, response, mock.Control.Priority); err != nil {
+					logging.Printf("Error recording request: %s\n", err.Error())
+				}
+			}
 		} else {
+			di.generateGraphQLResponse(&mRequest, mock)
 
 			di.VarsProcessor.Eval(&mRequest, mock)
+			if mock.Response.SOAPFault != nil && mock.Response.Body == "" {
+				soap.SetFault(&mock.Response)
+			}
+			di.validateGraphQLResponse(&mRequest, mock)
 
 			if !reflect.DeepEqual(definition.Notify{}, mock.Notify) {
 				go di.Notifier.Notify(mock)
@@ -80,27 +144,113 @@ func (di *Dispatcher) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				logging.Printf("Running crazy mode")
 				mock.Response.StatusCode = di.randomStatusCode(mock.Response.StatusCode)
 			}
-			if mock.Control.Delay > 0 {
-				logging.Printf("Adding a delay")
-				time.Sleep(time.Duration(mock.Control.Delay) * time.Second)
-			}
+			delayResponse(mock.Control)
 			response = mock.Response
 		}
 
 	} else {
 		response = mock.Response
+		if di.NearMisses {
+			di.setNearMissesBody(&response, result.NearMisses)
+		}
+	}
+
+	if result.Found && mock.Control.BytesPerSecond > 0 {
+		w = newThrottledWriter(w, mock.Control.BytesPerSecond)
+	}
+
+	if result.Found && len(result.Violations) == 0 {
+		if fault := pickFault(mock.Control.Faults); fault != nil && isKnownFault(fault) {
+			logging.Printf("Injecting fault: %s\n", fault.Type)
+			// the match is recorded first, as the faults which can't take over the connection abort the handler
+			result.Fault = faultDescription(fault)
+			di.recordMatch(definition.Match{MockName: mock.Name, Request: mRequest, Response: response, Result: result, Persist: mock.Persist})
+			di.writeFault(w, req, fault, &response)
+			return
+		}
 	}
 
 	//translate request
 	di.Translator.WriteHTTPResponseFromDefinition(&response, w)
 
 	//log to console
-	m := definition.Match{Request: mRequest, Response: response, Result: result, Persist: mock.Persist}
+	di.recordMatch(definition.Match{MockName: mock.Name, Request: mRequest, Response: response, Result: result, Persist: mock.Persist})
+}
+
+//delayResponse sleeps for the delay and the latency of the mock
+func delayResponse(control definition.Control) {
+	if delay := utils.GetResponseDelay(control); delay > 0 {
+		logging.Printf("Adding a delay of %s\n", delay)
+		time.Sleep(delay)
+	}
+}
+
+func (di *Dispatcher) recordMatch(m definition.Match) {
+	if di.Journal != nil {
+		di.Journal.Record(m)
+	}
 	go di.recordMatchData(m)
 }
 
-//Start initialize the HTTP mock server
+//validate returns the violations of the request against the OpenAPI operation and the GraphQL schema of the mock
+func (di *Dispatcher) validate(mRequest *definition.Request, mock *definition.Mock) []string {
+	return append(di.validateRequest(mRequest, mock), di.validateGraphQLRequest(mRequest, mock)...)
+}
+
+func (di *Dispatcher) validateRequest(mRequest *definition.Request, mock *definition.Mock) []string {
+	if di.Validator == nil || mock.OpenAPI == nil || mock.OpenAPI.SkipValidation {
+		return nil
+	}
+	violations, err := di.Validator.Validate(mRequest, mock.OpenAPI)
+	if err != nil {
+		logging.Printf("Error validating the request against %s %s: %s\n", mock.OpenAPI.Spec, mock.OpenAPI.Operation, err.Error())
+		return nil
+	}
+	for _, violation := range violations {
+		logging.Printf("Request violation: %s\n", violation)
+	}
+	return violations
+}
+
+func (di *Dispatcher) getValidationErrorResponse(violations []string) definition.Response {
+	response := definition.Response{StatusCode: di.ValidationStatusCode}
+	if response.StatusCode == 0 {
+		response.StatusCode = http.StatusBadRequest
+	}
+	body, _ := json.MarshalIndent(validationErrorBody{Message: "The request doesn't match the OpenAPI specification", Errors: violations}, "", "    ")
+	response.Headers = definition.Values{"Content-Type": []string{"application/json"}}
+	response.Body = string(body)
+	return response
+}
+
+func (di *Dispatcher) logNearMisses(nearMisses []definition.NearMiss) {
+	for _, nearMiss := range nearMisses {
+		logging.Printf("Near miss: %s Score: %.2f\n", nearMiss.MockName, nearMiss.Score)
+		for _, diff := range nearMiss.Diffs {
+			logging.Printf("  %s expected: %s actual: %s\n", diff.Field, diff.Expected, diff.Actual)
+		}
+	}
+}
+
+func (di *Dispatcher) setNearMissesBody(response *definition.Response, nearMisses []definition.NearMiss) {
+	body, err := json.MarshalIndent(notFoundBody{Message: "No mock definition matches the request", NearMisses: nearMisses}, "", "    ")
+	if err != nil {
+		logging.Printf("Error writing the near misses: %s\n", err.Error())
+		return
+	}
+	if response.Headers == nil {
+		response.Headers = make(definition.Values)
+	}
+	response.Headers["Content-Type"] = []string{"application/json"}
+	response.Body = string(body)
+}
+
+//Start initialize the HTTP mock server and the HTTPS one if there is TLS configuration
 func (di Dispatcher) Start() {
+	if di.TLSConfig != nil {
+		go di.startTLS()
+	}
+
 	addr := fmt.Sprintf("%s:%d", di.IP, di.Port)
 
 	err := http.ListenAndServe(addr, &di)
@@ -108,3 +258,12 @@ func (di Dispatcher) Start() {
 		logging.Fatalf("ListenAndServe: " + err.Error())
 	}
 }
+
+func (di Dispatcher) startTLS() {
+	srv := &http.Server{Addr: fmt.Sprintf("%s:%d", di.IP, di.TLSPort), Handler: &di, TLSConfig: di.TLSConfig}
+
+	err := srv.ListenAndServeTLS("", "")
+	if err != nil {
+		logging.Fatalf("ListenAndServeTLS: %s", err.Error())
+	}
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/graphql"
+	"github.com/vtrifonov/http-api-mock/match"
+	"github.com/vtrifonov/http-api-mock/persist"
+	"github.com/vtrifonov/http-api-mock/route"
+	"github.com/vtrifonov/http-api-mock/scenario"
+	"github.com/vtrifonov/http-api-mock/translate"
+	"github.com/vtrifonov/http-api-mock/vars"
+	"github.com/vtrifonov/http-api-mock/vars/fakedata"
+)
+
+func newTestDispatcher(mocks []definition.Mock) (*Dispatcher, *route.RequestRouter) {
+	router := route.NewRouter(mocks, match.MockMatch{}, nil)
+	mLog := make(chan definition.Match, 100)
+	dispatcher := &Dispatcher{
+		Router:     router,
+		Translator: translate.HTTPTranslator{},
+		VarsProcessor: vars.VarsProcessor{
+			FillerFactory:  vars.MockFillerFactory{},
+			FakeAdapter:    fakedata.FakeAdapter{},
+			PersistEngines: persist.GetNewPersistEngineBag(persist.FilePersister{PersistPath: "test_persist"}),
+		},
+		Mlog: mLog,
+	}
+	return dispatcher, router
+}
+
+func serve(dispatcher *Dispatcher, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	dispatcher.ServeHTTP(w, req)
+	return w
+}
+
+func TestDispatcher_InvalidGraphQLRequestKeepsState(t *testing.T) {
+	mock := definition.Mock{Name: "users"}
+	mock.Request.Method = "POST"
+	mock.Request.Path = "/graphql"
+	mock.Request.GraphQL = &definition.GraphQL{Schema: "graphql/schema.graphql"}
+	mock.Responses = []definition.Response{
+		{StatusCode: 200, Body: `{"data": {"users": []}}`},
+		{StatusCode: 200, Body: `{"data": {"users": [{"id": "1"}]}}`},
+	}
+	mock.Control.Scenario = "users"
+	mock.Control.NewState = "listed"
+
+	dispatcher, router := newTestDispatcher([]definition.Mock{mock})
+	dispatcher.GraphQL = graphql.NewSchemas("../config")
+	dispatcher.ValidateGraphQL = true
+
+	invalid := httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query": "{ users { nickname } }"}`))
+	if w := serve(dispatcher, invalid); !strings.Contains(w.Body.String(), `"errors"`) {
+		t.Fatal("The invalid request should get the GraphQL errors", w.Body.String())
+	}
+	if state := router.Scenarios.GetState("users"); state != scenario.StartedState {
+		t.Error("The invalid request should not change the scenario state", state)
+	}
+
+	valid := httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query": "{ users { id } }"}`))
+	if w := serve(dispatcher, valid); w.Body.String() != `{"data": {"users": []}}` {
+		t.Error("The valid request should get the first response of the sequence", w.Body.String())
+	}
+	if state := router.Scenarios.GetState("users"); state != "listed" {
+		t.Error("The valid request should change the scenario state", state)
+	}
+}
+
+func TestDispatcher_ProxyForwardsReceivedRequest(t *testing.T) {
+	var method, body, header string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := ioutil.ReadAll(r.Body)
+		method, body, header = r.Method, string(content), r.Header.Get("X-Request-Id")
+		w.Write([]byte("proxied"))
+	}))
+	defer backend.Close()
+
+	mock := definition.Mock{Name: "proxy"}
+	mock.Request.Method = "POST"
+	mock.Request.Path = "/orders"
+	mock.Request.Body = "*"
+	mock.Control.ProxyBaseURL = backend.URL
+	dispatcher, _ := newTestDispatcher([]definition.Mock{mock})
+
+	req := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"id": 1}`))
+	req.Header.Set("X-Request-Id", "abc")
+	if w := serve(dispatcher, req); w.Body.String() != "proxied" {
+		t.Fatal("The response of the proxied service should be returned", w.Body.String())
+	}
+	if method != "POST" || body != `{"id": 1}` || header != "abc" {
+		t.Error("The received request should be forwarded, not the mock definition", method, body, header)
+	}
+}
+
+func TestDispatcher_ProxyDelay(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("proxied"))
+	}))
+	defer backend.Close()
+
+	mock := definition.Mock{Name: "proxy"}
+	mock.Request.Method = "GET"
+	mock.Request.Path = "/orders"
+	mock.Control.ProxyBaseURL = backend.URL
+	mock.Control.Latency = &definition.Latency{DelayMs: 200}
+	dispatcher, _ := newTestDispatcher([]definition.Mock{mock})
+
+	start := time.Now()
+	if w := serve(dispatcher, httptest.NewRequest("GET", "/orders", nil)); w.Body.String() != "proxied" {
+		t.Fatal("The response of the proxied service should be returned", w.Body.String())
+	}
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Error("The latency should be added to the proxied response", elapsed)
+	}
+}
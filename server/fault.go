@@ -0,0 +1,143 @@
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+//randomDataLength is the number of the random bytes sent by the random data fault
+const randomDataLength = 1024
+
+//malformedResponse is an HTTP response with invalid status line and headers
+const malformedResponse = "HTTP/1.1 OK 200\r\nContent-Length: -1\r\nBroken Header\r\n\r\n"
+
+//pickFault returns the fault to inject, the probabilities of the faults are cumulative so at most one of them is picked
+func pickFault(faults []definition.Fault) *definition.Fault {
+	if len(faults) == 0 {
+		return nil
+	}
+	draw := rand.Float64()
+	total := 0.0
+	for i, fault := range faults {
+		probability := 1.0
+		if fault.Probability != nil {
+			probability = *fault.Probability
+		}
+		if probability <= 0 {
+			continue
+		}
+		total += probability
+		if draw < total {
+			return &faults[i]
+		}
+	}
+	return nil
+}
+
+//isKnownFault checks the fault type before anything is written, the unknown faults are skipped
+func isKnownFault(fault *definition.Fault) bool {
+	switch fault.Type {
+	case definition.FaultConnectionReset, definition.FaultEmptyResponse, definition.FaultMalformedResponse,
+		definition.FaultRandomData, definition.FaultPartialResponse, definition.FaultTimeout:
+		return true
+	}
+	logging.Printf("Unknown fault type: %s\n", fault.Type)
+	return false
+}
+
+//writeFault injects the known fault instead of the response
+func (di *Dispatcher) writeFault(w http.ResponseWriter, req *http.Request, fault *definition.Fault, response *definition.Response) {
+	switch fault.Type {
+	case definition.FaultConnectionReset:
+		resetConnection(hijack(w))
+	case definition.FaultEmptyResponse:
+		hijack(w).Close()
+	case definition.FaultMalformedResponse:
+		conn := hijack(w)
+		conn.Write([]byte(malformedResponse))
+		conn.Close()
+	case definition.FaultRandomData:
+		conn := hijack(w)
+		data := make([]byte, randomDataLength)
+		rand.Read(data)
+		conn.Write(data)
+		conn.Close()
+	case definition.FaultPartialResponse:
+		for header, values := range response.Headers {
+			for _, value := range values {
+				w.Header().Add(header, value)
+			}
+		}
+		// the full length is announced, so the client waits for the rest of the body
+		w.Header().Set("Content-Length", strconv.Itoa(len(response.Body)))
+		w.WriteHeader(response.StatusCode)
+		w.Write([]byte(response.Body[:len(response.Body)/2]))
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		hang(req, utils.GetDelay(fault.Duration, fault.DurationMs))
+		hijack(w).Close()
+	case definition.FaultTimeout:
+		hang(req, utils.GetDelay(fault.Duration, fault.DurationMs))
+		hijack(w).Close()
+	}
+}
+
+//hang blocks until the duration passes or the client disconnects, without duration it waits only for the client
+func hang(req *http.Request, duration time.Duration) {
+	var timeout <-chan time.Time
+	if duration > 0 {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-req.Context().Done():
+	case <-timeout:
+	}
+}
+
+//hijack takes over the connection of the response.
+//The HTTP/2 connections can't be taken over, so the handler is aborted instead and the client gets the stream reset.
+func hijack(w http.ResponseWriter) net.Conn {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		logging.Printf("The connection can't be taken over for the fault, resetting the stream\n")
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		logging.Printf("Error taking over the connection: %s, resetting the stream\n", err.Error())
+		panic(http.ErrAbortHandler)
+	}
+	return conn
+}
+
+//resetConnection closes the connection with TCP reset instead of the normal shutdown
+func resetConnection(conn net.Conn) {
+	raw := conn
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		raw = tlsConn.NetConn()
+	}
+	if tcpConn, ok := raw.(*net.TCPConn); ok {
+		tcpConn.SetLinger(0)
+	}
+	conn.Close()
+}
+
+//faultDescription is the fault recorded in the match result
+func faultDescription(fault *definition.Fault) string {
+	if duration := utils.GetDelay(fault.Duration, fault.DurationMs); duration > 0 {
+		return fmt.Sprintf("%s (%s)", fault.Type, duration)
+	}
+	return fault.Type
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+func probability(value float64) *float64 {
+	return &value
+}
+
+func TestPickFault(t *testing.T) {
+	if pickFault(nil) != nil {
+		t.Error("No fault should be picked without faults")
+	}
+	if fault := pickFault([]definition.Fault{{Type: definition.FaultTimeout}}); fault == nil || fault.Type != definition.FaultTimeout {
+		t.Error("The fault without probability should always be picked", fault)
+	}
+	disabled := []definition.Fault{{Type: definition.FaultTimeout, Probability: probability(0)}}
+	for i := 0; i < 100; i++ {
+		if fault := pickFault(disabled); fault != nil {
+			t.Fatal("The fault with zero probability should never be picked", fault)
+		}
+	}
+
+	faults := []definition.Fault{
+		{Type: definition.FaultConnectionReset, Probability: probability(0.25)},
+		{Type: definition.FaultEmptyResponse, Probability: probability(0.25)},
+	}
+	picked := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		if fault := pickFault(faults); fault != nil {
+			picked[fault.Type]++
+		} else {
+			picked[""]++
+		}
+	}
+	for _, key := range []string{definition.FaultConnectionReset, definition.FaultEmptyResponse} {
+		if picked[key] < 800 || picked[key] > 1200 {
+			t.Error("Unexpected number of faults", key, picked[key])
+		}
+	}
+	if picked[""] < 1800 || picked[""] > 2200 {
+		t.Error("Unexpected number of responses without fault", picked[""])
+	}
+}
+
+func faultHandler(fault definition.Fault, response definition.Response) http.Handler {
+	di := &Dispatcher{}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := response
+		di.writeFault(w, req, &fault, &r)
+	})
+}
+
+func serveFault(fault definition.Fault, response definition.Response) *httptest.Server {
+	return httptest.NewServer(faultHandler(fault, response))
+}
+
+func TestWriteFaultClosesConnection(t *testing.T) {
+	for _, faultType := range []string{definition.FaultConnectionReset, definition.FaultEmptyResponse, definition.FaultMalformedResponse, definition.FaultRandomData} {
+		srv := serveFault(definition.Fault{Type: faultType}, definition.Response{StatusCode: 200, Body: "ok"})
+		if res, err := http.Get(srv.URL); err == nil {
+			res.Body.Close()
+			t.Error("The request should fail", faultType, res.StatusCode)
+		}
+		srv.Close()
+	}
+}
+
+func TestWriteFaultMalformedResponse(t *testing.T) {
+	srv := serveFault(definition.Fault{Type: definition.FaultMalformedResponse}, definition.Response{StatusCode: 200})
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+	data, _ := ioutil.ReadAll(conn)
+	if string(data) != malformedResponse {
+		t.Error("Unexpected response", string(data))
+	}
+}
+
+func TestWriteFaultPartialResponse(t *testing.T) {
+	srv := serveFault(definition.Fault{Type: definition.FaultPartialResponse, Duration: 1}, definition.Response{StatusCode: 200, Body: "0123456789"})
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != 200 || res.ContentLength != 10 {
+		t.Error("The headers should be sent", res.StatusCode, res.ContentLength)
+	}
+	body, err := ioutil.ReadAll(bufio.NewReader(res.Body))
+	if err == nil || string(body) != "01234" {
+		t.Error("Only half of the body should be received", string(body), err)
+	}
+}
+
+func TestFaultDescription(t *testing.T) {
+	cases := map[string]definition.Fault{
+		"TIMEOUT":         {Type: definition.FaultTimeout},
+		"TIMEOUT (30s)":   {Type: definition.FaultTimeout, Duration: 30},
+		"TIMEOUT (1.5s)":  {Type: definition.FaultTimeout, Duration: 1, DurationMs: 500},
+		"TIMEOUT (250ms)": {Type: definition.FaultTimeout, DurationMs: 250},
+	}
+	for expected, fault := range cases {
+		if description := faultDescription(&fault); description != expected {
+			t.Errorf("The description should be %s, but it is %s", expected, description)
+		}
+	}
+}
+
+func TestWriteFaultHTTP2(t *testing.T) {
+	faults := []definition.Fault{
+		{Type: definition.FaultConnectionReset},
+		{Type: definition.FaultEmptyResponse},
+		{Type: definition.FaultMalformedResponse},
+		{Type: definition.FaultRandomData},
+		{Type: definition.FaultPartialResponse, DurationMs: 100},
+		{Type: definition.FaultTimeout, DurationMs: 100},
+	}
+	for _, fault := range faults {
+		srv := httptest.NewUnstartedServer(faultHandler(fault, definition.Response{StatusCode: 200, Body: "0123456789"}))
+		srv.EnableHTTP2 = true
+		srv.StartTLS()
+
+		res, err := srv.Client().Get(srv.URL)
+		if err == nil {
+			_, err = ioutil.ReadAll(res.Body)
+			res.Body.Close()
+			if res.ProtoMajor != 2 {
+				t.Error("The request should use HTTP/2", res.Proto)
+			}
+		}
+		if err == nil {
+			t.Error("The HTTP/2 stream should be reset", fault.Type)
+		}
+		srv.Close()
+	}
+}
+
+func TestIsKnownFault(t *testing.T) {
+	if !isKnownFault(&definition.Fault{Type: definition.FaultTimeout}) {
+		t.Error("The timeout fault should be known")
+	}
+	if isKnownFault(&definition.Fault{Type: "SLOW"}) {
+		t.Error("The unknown faults should not be written")
+	}
+}
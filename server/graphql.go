@@ -0,0 +1,99 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/graphql"
+	"github.com/vtrifonov/http-api-mock/logging"
+)
+
+//graphQLError is an error of the GraphQL responses
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+//graphQLErrorBody is the body of the GraphQL requests not valid against the schema
+type graphQLErrorBody struct {
+	Errors []graphQLError `json:"errors"`
+}
+
+//graphQLSchema returns the request and the schema of the GraphQL mocks having one
+func (di *Dispatcher) graphQLSchema(mRequest *definition.Request, mock *definition.Mock) (graphql.Request, string, bool) {
+	if di.GraphQL == nil || mock.Request.GraphQL == nil || mock.Request.GraphQL.Schema == "" {
+		return graphql.Request{}, "", false
+	}
+	request, found := graphql.ParseRequest(mRequest)
+	return request, mock.Request.GraphQL.Schema, found
+}
+
+func (di *Dispatcher) validateGraphQLRequest(mRequest *definition.Request, mock *definition.Mock) []string {
+	request, schema, found := di.graphQLSchema(mRequest, mock)
+	if !found || !di.ValidateGraphQL {
+		return nil
+	}
+	violations, err := di.GraphQL.Validate(schema, request)
+	if err != nil {
+		logging.Printf("Error validating the request against the GraphQL schema %s: %s\n", schema, err.Error())
+		return nil
+	}
+	for _, violation := range violations {
+		logging.Printf("Request violation: %s\n", violation)
+	}
+	return violations
+}
+
+//generateGraphQLResponse sets the response data of the GraphQL mocks without body
+func (di *Dispatcher) generateGraphQLResponse(mRequest *definition.Request, mock *definition.Mock) {
+	request, schema, found := di.graphQLSchema(mRequest, mock)
+	if !found || strings.TrimSpace(mock.Response.Body) != "" {
+		return
+	}
+	body, err := di.GraphQL.Generate(schema, request)
+	if err != nil {
+		logging.Printf("Error generating the GraphQL response from %s: %s\n", schema, err.Error())
+		return
+	}
+	mock.Response.Body = body
+	if mock.Response.StatusCode == 0 {
+		mock.Response.StatusCode = http.StatusOK
+	}
+	if mock.Response.Headers == nil {
+		mock.Response.Headers = make(definition.Values)
+	}
+	if _, ok := mock.Response.Headers["Content-Type"]; !ok {
+		mock.Response.Headers["Content-Type"] = []string{"application/json"}
+	}
+}
+
+//validateGraphQLResponse logs the differences between the response and the request operation
+func (di *Dispatcher) validateGraphQLResponse(mRequest *definition.Request, mock *definition.Mock) {
+	request, schema, found := di.graphQLSchema(mRequest, mock)
+	if !found {
+		return
+	}
+	violations, err := di.GraphQL.ValidateResponse(schema, request, mock.Response.Body)
+	if err != nil {
+		return
+	}
+	for _, violation := range violations {
+		logging.Printf("GraphQL response violation: %s\n", violation)
+	}
+}
+
+func (di *Dispatcher) getGraphQLErrorResponse(violations []string) definition.Response {
+	response := definition.Response{StatusCode: di.ValidationStatusCode}
+	if response.StatusCode == 0 {
+		response.StatusCode = http.StatusBadRequest
+	}
+	errors := make([]graphQLError, len(violations))
+	for i, violation := range violations {
+		errors[i] = graphQLError{Message: violation}
+	}
+	body, _ := json.MarshalIndent(graphQLErrorBody{Errors: errors}, "", "    ")
+	response.Headers = definition.Values{"Content-Type": []string{"application/json"}}
+	response.Body = string(body)
+	return response
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+//throttleSlicesPerSecond is the number of the writes per second of a throttled response
+const throttleSlicesPerSecond = 10
+
+//ErrHijackNotSupported when the connection of the throttled response can't be taken over
+var ErrHijackNotSupported = errors.New("The response writer doesn't support hijacking")
+
+//throttledWriter limits the response body to the bytes per second, the body is written and flushed in slices
+type throttledWriter struct {
+	http.ResponseWriter
+	bytesPerSecond int
+}
+
+func newThrottledWriter(w http.ResponseWriter, bytesPerSecond int) *throttledWriter {
+	return &throttledWriter{ResponseWriter: w, bytesPerSecond: bytesPerSecond}
+}
+
+func (tw *throttledWriter) Write(data []byte) (int, error) {
+	slice := tw.bytesPerSecond / throttleSlicesPerSecond
+	if slice < 1 {
+		slice = 1
+	}
+	interval := time.Second * time.Duration(slice) / time.Duration(tw.bytesPerSecond)
+
+	written := 0
+	for written < len(data) {
+		end := written + slice
+		if end > len(data) {
+			end = len(data)
+		}
+		n, err := tw.ResponseWriter.Write(data[written:end])
+		written += n
+		if err != nil {
+			return written, err
+		}
+		tw.Flush()
+		// the response ends right after the last slice
+		if written < len(data) {
+			time.Sleep(interval)
+		}
+	}
+	return written, nil
+}
+
+//Flush sends the buffered data to the client
+func (tw *throttledWriter) Flush() {
+	if flusher, ok := tw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+//Hijack lets the faults take over the connection of the throttled response
+func (tw *throttledWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := tw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, ErrHijackNotSupported
+	}
+	return hijacker.Hijack()
+}
@@ -0,0 +1,34 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestThrottledWriter(t *testing.T) {
+	var writing time.Duration
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		newThrottledWriter(w, 100).Write(make([]byte, 50))
+		writing = time.Since(start)
+	}))
+	defer srv.Close()
+
+	start := time.Now()
+	res, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+	if elapsed := time.Since(start); len(body) != 50 || elapsed < 350*time.Millisecond || elapsed > 2*time.Second {
+		t.Error("The body should be written with 100 bytes per second", len(body), elapsed)
+	}
+	// the 5 slices are written with 4 pauses, there is no pause after the last one
+	if writing >= 450*time.Millisecond {
+		t.Error("The writer should not wait after the last slice", writing)
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/match"
+	"github.com/vtrifonov/http-api-mock/utils"
+	"github.com/vtrifonov/http-api-mock/vars"
+)
+
+//closeTimeout is the time given to the client to answer the close frame before the connection is dropped
+const closeTimeout = 5 * time.Second
+
+// the origin is not checked, so any client can connect
+var upgrader = websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+
+//webSocketSession runs the script of the mock on an upgraded connection
+type webSocketSession struct {
+	ws            *websocket.Conn
+	request       definition.Request
+	mock          *definition.Mock
+	varsProcessor vars.VarsProcessor
+	// the writes are serialized, as the connection supports only one concurrent writer
+	writeLock sync.Mutex
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+//serveWebSocket upgrades the request and runs the WebSocket script of the mock until the connection is closed.
+//The origin is not checked, so any client can connect.
+func (di *Dispatcher) serveWebSocket(w http.ResponseWriter, req *http.Request, mRequest definition.Request, mock *definition.Mock) {
+	ws, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		// the upgrader has already replied with the error status
+		logging.Printf("Error upgrading the WebSocket connection: %s\n", err.Error())
+		return
+	}
+	session := &webSocketSession{ws: ws, request: mRequest, mock: mock, varsProcessor: di.VarsProcessor, done: make(chan struct{})}
+	session.run()
+}
+
+//recordWebSocketMatch logs the connection before the upgrade, as the script runs until the connection is closed
+func (di *Dispatcher) recordWebSocketMatch(mRequest definition.Request, mock *definition.Mock, result definition.Result) {
+	response := definition.Response{StatusCode: http.StatusSwitchingProtocols}
+	di.recordMatch(definition.Match{MockName: mock.Name, Request: mRequest, Response: response, Result: result, Persist: mock.Persist})
+}
+
+func (s *webSocketSession) run() {
+	defer s.ws.Close()
+	script := s.mock.WebSocket
+
+	go func() {
+		// the delay of the script close starts after the on connect messages are sent
+		if s.sendAll(s.request, script.OnConnect) && script.Close != nil && s.wait(utils.GetDelay(script.Close.Delay, script.Close.DelayMs)) {
+			s.close(script.Close)
+		}
+	}()
+	for _, periodic := range script.Periodic {
+		go s.sendPeriodic(periodic)
+	}
+
+	for {
+		// the client answer to the close frame ends the loop as well
+		_, message, err := s.ws.ReadMessage()
+		if err != nil {
+			s.close(nil)
+			return
+		}
+		logging.Printf("WebSocket message received: %s\n", message)
+		s.reply(string(message))
+	}
+}
+
+//reply sends the messages of the first reply matching the incoming message, the message is the request body for the vars
+func (s *webSocketSession) reply(message string) {
+	matcher := match.MockMatch{}
+	for i := range s.mock.WebSocket.Replies {
+		reply := &s.mock.WebSocket.Replies[i]
+		if !matcher.MatchMessage(message, reply) {
+			continue
+		}
+		request := s.request
+		request.Body = message
+		if s.sendAll(request, reply.Messages) && reply.Close != nil && s.wait(utils.GetDelay(reply.Close.Delay, reply.Close.DelayMs)) {
+			s.close(reply.Close)
+		}
+		return
+	}
+	logging.Printf("No WebSocket reply matches the message\n")
+}
+
+//sendAll sends the messages in order, it returns false when the connection is closed
+func (s *webSocketSession) sendAll(request definition.Request, messages []definition.WebSocketMessage) bool {
+	for _, message := range messages {
+		if !s.wait(utils.GetDelay(message.Delay, message.DelayMs)) || !s.send(request, message.Body) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *webSocketSession) sendPeriodic(periodic definition.WebSocketPeriodic) {
+	interval := utils.GetDelay(periodic.Interval, periodic.IntervalMs)
+	if interval <= 0 {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for sent := 0; periodic.Count <= 0 || sent < periodic.Count; sent++ {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if !s.send(s.request, periodic.Body) {
+				return
+			}
+		}
+	}
+}
+
+func (s *webSocketSession) send(request definition.Request, body string) bool {
+	text := s.varsProcessor.EvalText(&request, s.mock, body)
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+	if err := s.ws.WriteMessage(websocket.TextMessage, []byte(text)); err != nil {
+		logging.Printf("Error sending the WebSocket message: %s\n", err.Error())
+		return false
+	}
+	return true
+}
+
+//wait returns false when the connection is closed before the delay passes
+func (s *webSocketSession) wait(delay time.Duration) bool {
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-s.done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+//close sends a single close frame with the code and reason of the script, the code is 1000 when it is not set.
+//The connection is dropped by run when the client answers or the close timeout passes.
+//Without a script the client has closed the connection already, so only the messages are stopped.
+func (s *webSocketSession) close(closing *definition.WebSocketClose) {
+	s.closeOnce.Do(func() {
+		s.writeLock.Lock()
+		defer s.writeLock.Unlock()
+		close(s.done)
+		if closing == nil {
+			return
+		}
+		code := closing.Code
+		if code <= 0 {
+			code = websocket.CloseNormalClosure
+		}
+		logging.Printf("Closing the WebSocket connection with code %d\n", code)
+		deadline := time.Now().Add(closeTimeout)
+		if err := s.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, closing.Reason), deadline); err != nil {
+			logging.Printf("Error closing the WebSocket connection: %s\n", err.Error())
+		}
+		s.ws.SetReadDeadline(deadline)
+	})
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+func dialWebSocket(t *testing.T, script definition.WebSocket, path string) *websocket.Conn {
+	return dialWebSocketMock(t, definition.Mock{WebSocket: &script}, path)
+}
+
+func dialWebSocketMock(t *testing.T, mock definition.Mock, path string) *websocket.Conn {
+	mock.Name = "chat"
+	mock.Request.Method = "GET"
+	mock.Request.Path = "/ws/chat/:room"
+
+	dispatcher, _ := newTestDispatcher([]definition.Mock{mock})
+	server := httptest.NewServer(dispatcher)
+	t.Cleanup(server.Close)
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+path, nil)
+	if err != nil {
+		t.Fatal("The WebSocket connection should be upgraded", err)
+	}
+	t.Cleanup(func() { ws.Close() })
+	ws.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return ws
+}
+
+func readMessage(t *testing.T, ws *websocket.Conn) string {
+	_, message, err := ws.ReadMessage()
+	if err != nil {
+		t.Fatal("A message should be received", err)
+	}
+	return string(message)
+}
+
+func TestWebSocket_OnConnect(t *testing.T) {
+	script := definition.WebSocket{OnConnect: []definition.WebSocketMessage{
+		{Body: "welcome to {{request.path.room}}"},
+		{Body: "second"},
+	}}
+	ws := dialWebSocket(t, script, "/ws/chat/lobby")
+
+	if message := readMessage(t, ws); message != "welcome to lobby" {
+		t.Error("The first message should be sent with the vars replaced", message)
+	}
+	if message := readMessage(t, ws); message != "second" {
+		t.Error("The messages should be sent in order", message)
+	}
+}
+
+func TestWebSocket_Replies(t *testing.T) {
+	script := definition.WebSocket{Replies: []definition.WebSocketReply{
+		{
+			BodyJSON:                  []byte(`{"type": "ping"}`),
+			BodyJSONIgnoreExtraFields: true,
+			Messages:                  []definition.WebSocketMessage{{Body: "pong {{request.body.id}}"}},
+		},
+		{
+			Body:     "hello*",
+			Messages: []definition.WebSocketMessage{{Body: "hi"}},
+		},
+		{
+			Messages: []definition.WebSocketMessage{{Body: "echo {{request.body}}"}},
+		},
+	}}
+	ws := dialWebSocket(t, script, "/ws/chat/lobby")
+
+	cases := map[string]string{
+		`{"type": "ping", "id": "7"}`: "pong 7",
+		"hello there":                 "hi",
+		"anything":                    "echo anything",
+	}
+	for sent, expected := range cases {
+		if err := ws.WriteMessage(websocket.TextMessage, []byte(sent)); err != nil {
+			t.Fatal("The message should be sent", err)
+		}
+		if message := readMessage(t, ws); message != expected {
+			t.Errorf("The reply of %s should be %s, but it is %s", sent, expected, message)
+		}
+	}
+}
+
+func TestWebSocket_PeriodicCount(t *testing.T) {
+	script := definition.WebSocket{
+		Periodic: []definition.WebSocketPeriodic{{Body: "tick", IntervalMs: 100, Count: 2}},
+		Close:    &definition.WebSocketClose{Code: 4000, DelayMs: 500},
+	}
+	ws := dialWebSocket(t, script, "/ws/chat/lobby")
+
+	ticks := 0
+	for {
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			break
+		}
+		if string(message) != "tick" {
+			t.Error("Only the periodic messages should be sent", string(message))
+		}
+		ticks++
+	}
+	if ticks != 2 {
+		t.Error("The periodic message should be sent count times", ticks)
+	}
+}
+
+func TestWebSocket_CloseCode(t *testing.T) {
+	script := definition.WebSocket{Replies: []definition.WebSocketReply{
+		{
+			Body:     "bye",
+			Messages: []definition.WebSocketMessage{{Body: "see you"}},
+			Close:    &definition.WebSocketClose{Code: 4001, Reason: "client left"},
+		},
+	}}
+	ws := dialWebSocket(t, script, "/ws/chat/lobby")
+
+	closes := 0
+	ws.SetCloseHandler(func(code int, reason string) error {
+		closes++
+		if code != 4001 || reason != "client left" {
+			t.Errorf("The connection should be closed with 4001 client left, but it is %d %s", code, reason)
+		}
+		return ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, ""), time.Now().Add(time.Second))
+	})
+
+	ws.WriteMessage(websocket.TextMessage, []byte("bye"))
+	if message := readMessage(t, ws); message != "see you" {
+		t.Error("The reply should be sent before closing", message)
+	}
+	_, _, err := ws.ReadMessage()
+	if !websocket.IsCloseError(err, 4001) {
+		t.Error("The connection should be closed by the script", err)
+	}
+	// the server drops the connection after the close frame, so no second frame is read
+	ws.UnderlyingConn().SetReadDeadline(time.Now().Add(time.Second))
+	buf := make([]byte, 1)
+	if n, _ := ws.UnderlyingConn().Read(buf); n > 0 {
+		t.Error("Only one close frame should be sent")
+	}
+	if closes != 1 {
+		t.Error("One close frame should be received", closes)
+	}
+}
+
+func TestWebSocket_CloseWithoutCode(t *testing.T) {
+	script := definition.WebSocket{Close: &definition.WebSocketClose{}}
+	ws := dialWebSocket(t, script, "/ws/chat/lobby")
+
+	_, _, err := ws.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Error("The connection should be closed with 1000 when there is no code", err)
+	}
+}
+
+func TestWebSocket_Delay(t *testing.T) {
+	mock := definition.Mock{WebSocket: &definition.WebSocket{OnConnect: []definition.WebSocketMessage{{Body: "welcome"}}}}
+	mock.Control.Latency = &definition.Latency{DelayMs: 200}
+
+	start := time.Now()
+	ws := dialWebSocketMock(t, mock, "/ws/chat/lobby")
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Error("The latency should delay the handshake", elapsed)
+	}
+	if message := readMessage(t, ws); message != "welcome" {
+		t.Error("The script should run after the delay", message)
+	}
+}
@@ -0,0 +1,110 @@
+package soap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+const (
+	//Namespace11 is the SOAP 1.1 envelope namespace
+	Namespace11 = "http://schemas.xmlsoap.org/soap/envelope/"
+	//Namespace12 is the SOAP 1.2 envelope namespace
+	Namespace12 = "http://www.w3.org/2003/05/soap-envelope"
+)
+
+//faultCodes12 converts the SOAP 1.1 fault codes to the SOAP 1.2 ones
+var faultCodes12 = map[string]string{
+	"Client": "Sender",
+	"Server": "Receiver",
+}
+
+//faultCodes11 converts the SOAP 1.2 fault codes to the SOAP 1.1 ones
+var faultCodes11 = map[string]string{
+	"Sender":   "Client",
+	"Receiver": "Server",
+}
+
+//SetFault replaces the response body with the SOAP fault envelope and sets the content type of the SOAP version.
+//The status code is 500 unless the response has one.
+func SetFault(response *definition.Response) {
+	fault := response.SOAPFault
+	contentType := "text/xml; charset=utf-8"
+	if fault.Version == "1.2" {
+		contentType = "application/soap+xml; charset=utf-8"
+		response.Body = buildFault12(fault)
+	} else {
+		response.Body = buildFault11(fault)
+	}
+	if response.StatusCode == 0 {
+		response.StatusCode = http.StatusInternalServerError
+	}
+	if response.Headers == nil {
+		response.Headers = make(definition.Values)
+	}
+	response.Headers["Content-Type"] = []string{contentType}
+}
+
+func buildFault11(fault *definition.SOAPFault) string {
+	code := faultCode(fault.Code, "Server", faultCodes11)
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString(`<soap:Envelope xmlns:soap="` + Namespace11 + `">` + "\n")
+	buf.WriteString("  <soap:Body>\n    <soap:Fault>\n")
+	fmt.Fprintf(&buf, "      <faultcode>%s</faultcode>\n", escape(code))
+	fmt.Fprintf(&buf, "      <faultstring>%s</faultstring>\n", escape(fault.Reason))
+	if fault.Actor != "" {
+		fmt.Fprintf(&buf, "      <faultactor>%s</faultactor>\n", escape(fault.Actor))
+	}
+	if fault.Detail != "" {
+		fmt.Fprintf(&buf, "      <detail>%s</detail>\n", fault.Detail)
+	}
+	buf.WriteString("    </soap:Fault>\n  </soap:Body>\n</soap:Envelope>")
+	return buf.String()
+}
+
+func buildFault12(fault *definition.SOAPFault) string {
+	code := faultCode(fault.Code, "Receiver", faultCodes12)
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buf.WriteString(`<soap:Envelope xmlns:soap="` + Namespace12 + `">` + "\n")
+	buf.WriteString("  <soap:Body>\n    <soap:Fault>\n")
+	fmt.Fprintf(&buf, "      <soap:Code><soap:Value>%s</soap:Value></soap:Code>\n", escape(code))
+	fmt.Fprintf(&buf, "      <soap:Reason><soap:Text xml:lang=\"en\">%s</soap:Text></soap:Reason>\n", escape(fault.Reason))
+	if fault.Actor != "" {
+		fmt.Fprintf(&buf, "      <soap:Role>%s</soap:Role>\n", escape(fault.Actor))
+	}
+	if fault.Detail != "" {
+		fmt.Fprintf(&buf, "      <soap:Detail>%s</soap:Detail>\n", fault.Detail)
+	}
+	buf.WriteString("    </soap:Fault>\n  </soap:Body>\n</soap:Envelope>")
+	return buf.String()
+}
+
+//faultCode returns the fault code with the envelope prefix, the codes of the other SOAP version are converted
+func faultCode(code string, defaultCode string, converted map[string]string) string {
+	if code == "" {
+		code = defaultCode
+	}
+	// the codes with a custom prefix are used as they are
+	if i := strings.Index(code, ":"); i >= 0 {
+		if prefix := code[:i]; prefix != "soap" {
+			return code
+		}
+		code = code[i+1:]
+	}
+	if value, ok := converted[code]; ok {
+		code = value
+	}
+	return "soap:" + code
+}
+
+func escape(value string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
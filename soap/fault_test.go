@@ -0,0 +1,62 @@
+package soap
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/utils"
+)
+
+func TestSetFault11(t *testing.T) {
+	response := definition.Response{SOAPFault: &definition.SOAPFault{Code: "Client", Reason: "Invalid id <a&b>", Detail: "<e:Error xmlns:e=\"urn:errors\">E1</e:Error>"}}
+	SetFault(&response)
+	if response.StatusCode != 500 || response.Headers["Content-Type"][0] != "text/xml; charset=utf-8" {
+		t.Error("Unexpected status or content type", response.StatusCode, response.Headers)
+	}
+	namespaces := map[string]string{"s": Namespace11, "e": "urn:errors"}
+	for expr, expected := range map[string]string{
+		"//s:Fault/faultcode":      "soap:Client",
+		"//s:Fault/faultstring":    "Invalid id <a&b>",
+		"//s:Fault/detail/e:Error": "E1",
+	} {
+		values, err := utils.GetXPathValues(response.Body, expr, namespaces)
+		if err != nil || len(values) != 1 || values[0] != expected {
+			t.Error("Unexpected fault value", expr, values, err)
+		}
+	}
+	if strings.Contains(response.Body, "faultactor") {
+		t.Error("The empty actor should not be added")
+	}
+}
+
+func TestSetFault12(t *testing.T) {
+	response := definition.Response{StatusCode: 400, SOAPFault: &definition.SOAPFault{Version: "1.2", Code: "Client", Reason: "Invalid", Actor: "urn:gateway"}}
+	SetFault(&response)
+	if response.StatusCode != 400 || !strings.HasPrefix(response.Headers["Content-Type"][0], "application/soap+xml") {
+		t.Error("Unexpected status or content type", response.StatusCode, response.Headers)
+	}
+	namespaces := map[string]string{"s": Namespace12}
+	for expr, expected := range map[string]string{
+		"//s:Code/s:Value":  "soap:Sender",
+		"//s:Reason/s:Text": "Invalid",
+		"//s:Role":          "urn:gateway",
+	} {
+		values, err := utils.GetXPathValues(response.Body, expr, namespaces)
+		if err != nil || len(values) != 1 || values[0] != expected {
+			t.Error("Unexpected fault value", expr, values, err)
+		}
+	}
+}
+
+func TestFaultCode(t *testing.T) {
+	if code := faultCode("", "Server", faultCodes11); code != "soap:Server" {
+		t.Error("The default code should be used", code)
+	}
+	if code := faultCode("soap:Receiver", "Server", faultCodes11); code != "soap:Server" {
+		t.Error("The SOAP 1.2 codes should be converted", code)
+	}
+	if code := faultCode("app:Throttled", "Server", faultCodes11); code != "app:Throttled" {
+		t.Error("The custom codes should not be changed", code)
+	}
+}
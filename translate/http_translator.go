@@ -1,13 +1,20 @@
 package translate
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/utils"
 )
 
 //HTTPTranslator is and adaptor beteewn the http and mock definition.
@@ -20,6 +27,12 @@ func (t HTTPTranslator) BuildRequestDefinitionFromHTTP(req *http.Request) defini
 	res := definition.Request{}
 	res.Method = req.Method
 	res.Path = req.URL.Path
+	res.Host = req.Host
+	res.Scheme = "http"
+	if req.TLS != nil {
+		res.Scheme = "https"
+	}
+	res.RemoteAddr = req.RemoteAddr
 	res.Headers = make(definition.Values)
 	for header, values := range req.Header {
 		if header != "Cookie" {
@@ -37,11 +50,32 @@ func (t HTTPTranslator) BuildRequestDefinitionFromHTTP(req *http.Request) defini
 		res.QueryStringParameters[name] = values
 	}
 
+	if req.TLS != nil && len(req.TLS.PeerCertificates) > 0 {
+		res.TLS = t.buildClientCertificate(req.TLS.PeerCertificates[0])
+	}
+
 	body, _ := ioutil.ReadAll(req.Body)
 	res.Body = string(body)
 	return res
 }
 
+func (t HTTPTranslator) buildClientCertificate(cert *x509.Certificate) *definition.ClientCertificate {
+	res := &definition.ClientCertificate{}
+	res.Subject = cert.Subject.String()
+	res.Issuer = cert.Issuer.String()
+	res.SANs = append(res.SANs, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		res.SANs = append(res.SANs, ip.String())
+	}
+	res.SANs = append(res.SANs, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		res.SANs = append(res.SANs, uri.String())
+	}
+	fingerprint := sha256.Sum256(cert.Raw)
+	res.Fingerprint = hex.EncodeToString(fingerprint[:])
+	return res
+}
+
 //WriteHTTPResponseFromDefinition read a mock response and write a http response.
 func (t HTTPTranslator) WriteHTTPResponseFromDefinition(fr *definition.Response, w http.ResponseWriter) {
 
@@ -59,6 +93,81 @@ func (t HTTPTranslator) WriteHTTPResponseFromDefinition(fr *definition.Response,
 		w.Header().Add("Set-Cookie", strings.Join(cookies, ";"))
 	}
 
+	if len(fr.Events) > 0 {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "text/event-stream")
+		}
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+
 	w.WriteHeader(fr.StatusCode)
 	io.WriteString(w, fr.Body)
+
+	if len(fr.Chunks) > 0 || len(fr.Events) > 0 {
+		t.writeStream(fr, w)
+	}
+}
+
+//writeStream writes and flushes the chunks and the events one by one, it stops when the client disconnects
+func (t HTTPTranslator) writeStream(fr *definition.Response, w http.ResponseWriter) {
+	flusher, _ := w.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	flush()
+
+	parts := append([]definition.ResponseChunk{}, fr.Chunks...)
+	for _, event := range fr.Events {
+		parts = append(parts, definition.ResponseChunk{Body: formatEvent(event), Delay: event.Delay, DelayMs: event.DelayMs})
+	}
+	for i, part := range parts {
+		if delay := utils.GetDelay(part.Delay, part.DelayMs); delay > 0 {
+			time.Sleep(delay)
+		}
+		if _, err := io.WriteString(w, part.Body); err != nil {
+			return
+		}
+		flush()
+		if fr.DisconnectAfter > 0 && i+1 == fr.DisconnectAfter {
+			t.disconnect(w)
+			return
+		}
+	}
+}
+
+//disconnect closes the connection without ending the response, so the client gets an incomplete body.
+//The HTTP/2 connections can't be taken over, so the handler is aborted instead and the client gets the stream reset.
+func (t HTTPTranslator) disconnect(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		logging.Printf("The connection can't be taken over for the disconnect, resetting the stream\n")
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		logging.Printf("Error taking over the connection: %s, resetting the stream\n", err.Error())
+		panic(http.ErrAbortHandler)
+	}
+	conn.Close()
+}
+
+//formatEvent returns the event in the text/event-stream format
+func formatEvent(event definition.SSEEvent) string {
+	var buf bytes.Buffer
+	if event.ID != "" {
+		fmt.Fprintf(&buf, "id: %s\n", event.ID)
+	}
+	if event.Event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", event.Event)
+	}
+	if event.Retry > 0 {
+		fmt.Fprintf(&buf, "retry: %d\n", event.Retry)
+	}
+	for _, line := range strings.Split(event.Data, "\n") {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteString("\n")
+	return buf.String()
 }
@@ -0,0 +1,124 @@
+package translate
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+func serveResponse(response definition.Response) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := response
+		HTTPTranslator{}.WriteHTTPResponseFromDefinition(&r, w)
+	}))
+}
+
+func TestWriteChunks(t *testing.T) {
+	srv := serveResponse(definition.Response{StatusCode: 200, Body: "start\n", Chunks: []definition.ResponseChunk{{Body: "10%\n"}, {Body: "100%\n"}}})
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if len(res.TransferEncoding) == 0 || res.TransferEncoding[0] != "chunked" {
+		t.Error("The response should be chunked", res.TransferEncoding)
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil || string(body) != "start\n10%\n100%\n" {
+		t.Error("Unexpected body", string(body), err)
+	}
+}
+
+func TestWriteEvents(t *testing.T) {
+	events := []definition.SSEEvent{
+		{ID: "1", Event: "progress", Data: "10"},
+		{Data: "line 1\nline 2", Retry: 5000},
+	}
+	srv := serveResponse(definition.Response{StatusCode: 200, Events: events})
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.Header.Get("Content-Type") != "text/event-stream" || res.Header.Get("Cache-Control") != "no-cache" {
+		t.Error("Unexpected headers", res.Header)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	expected := "id: 1\nevent: progress\ndata: 10\n\nretry: 5000\ndata: line 1\ndata: line 2\n\n"
+	if string(body) != expected {
+		t.Error("Unexpected events", string(body))
+	}
+}
+
+func TestWriteStreamDelays(t *testing.T) {
+	chunks := []definition.ResponseChunk{{Body: "chunk\n", DelayMs: 150}}
+	events := []definition.SSEEvent{{Data: "event", DelayMs: 150}}
+	srv := serveResponse(definition.Response{StatusCode: 200, Chunks: chunks, Events: events})
+	defer srv.Close()
+
+	start := time.Now()
+	res, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond || elapsed > 2*time.Second {
+		t.Error("The chunks and the events should be delayed in milliseconds", elapsed)
+	}
+	if string(body) != "chunk\ndata: event\n\n" {
+		t.Error("Unexpected body", string(body))
+	}
+}
+
+func TestWriteStreamDisconnect(t *testing.T) {
+	chunks := []definition.ResponseChunk{{Body: "first\n"}, {Body: "second\n"}}
+	srv := serveResponse(definition.Response{StatusCode: 200, Chunks: chunks, DisconnectAfter: 1})
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	reader := bufio.NewReader(res.Body)
+	if line, err := reader.ReadString('\n'); err != nil || line != "first\n" {
+		t.Error("The first chunk should be received", line, err)
+	}
+	if rest, err := ioutil.ReadAll(reader); err == nil || len(rest) > 0 {
+		t.Error("The stream should be interrupted", string(rest), err)
+	}
+}
+
+func TestWriteStreamDisconnectHTTP2(t *testing.T) {
+	chunks := []definition.ResponseChunk{{Body: "first\n"}, {Body: "second\n"}}
+	response := definition.Response{StatusCode: 200, Chunks: chunks, DisconnectAfter: 1}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := response
+		HTTPTranslator{}.WriteHTTPResponseFromDefinition(&r, w)
+	}))
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	defer srv.Close()
+
+	res, err := srv.Client().Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.ProtoMajor != 2 {
+		t.Error("The request should use HTTP/2", res.Proto)
+	}
+	if body, err := ioutil.ReadAll(res.Body); err == nil || string(body) != "first\n" {
+		t.Error("The stream should be reset after the first chunk", string(body), err)
+	}
+}
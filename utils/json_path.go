@@ -0,0 +1,201 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//ErrInvalidJSONPath when the JSONPath expression can't be parsed
+var ErrInvalidJSONPath = errors.New("Invalid JSONPath expression")
+
+//jsonPathStep is a single step of a JSONPath expression
+type jsonPathStep struct {
+	name      string // object property name
+	index     int    // array index, negative values are counted from the end
+	isIndex   bool   // the step selects an array item
+	wildcard  bool   // the step selects all the children
+	recursive bool   // the step selects all the descendants (..)
+}
+
+//GetJSONPathValues returns all the values in the JSON document selected by the JSONPath expression.
+//The supported syntax is $.name, $['name'], $.items[0], $.items[-1], $.items[*], $.*, $..name and $..[0]
+func GetJSONPathValues(input string, path string) ([]interface{}, error) {
+	steps, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	var document interface{}
+	if err := json.Unmarshal([]byte(input), &document); err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{document}
+	for _, step := range steps {
+		values = step.apply(values)
+	}
+	return values, nil
+}
+
+func (step jsonPathStep) apply(values []interface{}) []interface{} {
+	if step.recursive {
+		values = getDescendants(values)
+	}
+	results := []interface{}{}
+	for _, value := range values {
+		switch item := value.(type) {
+		case map[string]interface{}:
+			if step.wildcard {
+				for _, child := range item {
+					results = append(results, child)
+				}
+			} else if child, ok := item[step.name]; ok && !step.isIndex {
+				results = append(results, child)
+			}
+		case []interface{}:
+			if step.wildcard {
+				results = append(results, item...)
+			} else if step.isIndex {
+				index := step.index
+				if index < 0 {
+					index += len(item)
+				}
+				if index >= 0 && index < len(item) {
+					results = append(results, item[index])
+				}
+			}
+		}
+	}
+	return results
+}
+
+//getDescendants returns the values and all their descendants
+func getDescendants(values []interface{}) []interface{} {
+	results := []interface{}{}
+	for _, value := range values {
+		results = append(results, value)
+		switch item := value.(type) {
+		case map[string]interface{}:
+			for _, child := range item {
+				results = append(results, getDescendants([]interface{}{child})...)
+			}
+		case []interface{}:
+			results = append(results, getDescendants(item)...)
+		}
+	}
+	return results
+}
+
+func parseJSONPath(path string) ([]jsonPathStep, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, ErrInvalidJSONPath
+	}
+	steps := []jsonPathStep{}
+	rest := path[1:]
+	for len(rest) > 0 {
+		step := jsonPathStep{}
+		if strings.HasPrefix(rest, "..") {
+			step.recursive = true
+			rest = rest[1:]
+			// the recursive descent can be followed by a bracket selector like $..[0]
+			if strings.HasPrefix(rest, ".[") {
+				rest = rest[1:]
+			}
+		}
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			step.name = rest[:end]
+			rest = rest[end:]
+			if step.name == "" {
+				return nil, ErrInvalidJSONPath
+			}
+			step.wildcard = step.name == "*"
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, ErrInvalidJSONPath
+			}
+			selector := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			if err := step.parseSelector(selector); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ErrInvalidJSONPath
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func (step *jsonPathStep) parseSelector(selector string) error {
+	if selector == "*" {
+		step.wildcard = true
+		return nil
+	}
+	if len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0] {
+		step.name = selector[1 : len(selector)-1]
+		return nil
+	}
+	index, err := strconv.Atoi(selector)
+	if err != nil {
+		return ErrInvalidJSONPath
+	}
+	step.index = index
+	step.isIndex = true
+	return nil
+}
+
+//JSONValueToString returns the string representation of a JSON value, objects and arrays are serialized.
+func JSONValueToString(value interface{}) string {
+	switch item := value.(type) {
+	case string:
+		return item
+	case float64:
+		return strconv.FormatFloat(item, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(item)
+	case nil:
+		return "null"
+	}
+	result, _ := JSONSerialize(value)
+	return result
+}
+
+//JSONValuesAreEqual checks whether the actual JSON value is equal to the expected one regardless of the keys order.
+//When ignoreExtraFields is set the actual objects may have properties which are not expected.
+func JSONValuesAreEqual(expected interface{}, actual interface{}, ignoreExtraFields bool) bool {
+	switch expectedItem := expected.(type) {
+	case map[string]interface{}:
+		actualItem, ok := actual.(map[string]interface{})
+		if !ok || (!ignoreExtraFields && len(actualItem) != len(expectedItem)) {
+			return false
+		}
+		for key, value := range expectedItem {
+			actualValue, exists := actualItem[key]
+			if !exists || !JSONValuesAreEqual(value, actualValue, ignoreExtraFields) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actualItem, ok := actual.([]interface{})
+		if !ok || len(actualItem) != len(expectedItem) {
+			return false
+		}
+		for i, value := range expectedItem {
+			if !JSONValuesAreEqual(value, actualItem[i], ignoreExtraFields) {
+				return false
+			}
+		}
+		return true
+	}
+	return expected == actual
+}
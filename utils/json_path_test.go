@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSONPath_GetJSONPathValues(t *testing.T) {
+	input := "{ \"store\": { \"books\": [ { \"title\": \"A\", \"price\": 8.95 }, { \"title\": \"B\", \"price\": 12 } ], \"name\": \"Shop\" } }"
+
+	tests := map[string]string{
+		"$.store.name":                "[\"Shop\"]",
+		"$['store']['name']":          "[\"Shop\"]",
+		"$.store.books[0].title":      "[\"A\"]",
+		"$.store.books[-1].price":     "[12]",
+		"$.store.books[*].title":      "[\"A\",\"B\"]",
+		"$..price":                    "[8.95,12]",
+		"$..[0].title":                "[\"A\"]",
+		"$.store..['name']":           "[\"Shop\"]",
+		"$.store.missing":             "[]",
+		"$.store.books[5]":            "[]",
+		"$.store.books[1][\"title\"]": "[\"B\"]",
+	}
+
+	for path, expected := range tests {
+		values, err := GetJSONPathValues(input, path)
+		if err != nil {
+			t.Error(path, err)
+			continue
+		}
+		result, _ := json.Marshal(values)
+		if string(result) != expected {
+			t.Error("The result differs from the expected result", path, string(result), expected)
+		}
+	}
+}
+
+func TestJSONPath_InvalidPath(t *testing.T) {
+	if _, err := GetJSONPathValues("{}", "store.name"); err != ErrInvalidJSONPath {
+		t.Error("The path should start with $", err)
+	}
+	if _, err := GetJSONPathValues("{}", "$.store[name"); err != ErrInvalidJSONPath {
+		t.Error("The path should be invalid", err)
+	}
+}
+
+func TestJSONPath_JSONValuesAreEqual(t *testing.T) {
+	var expected, actual interface{}
+	json.Unmarshal([]byte("{ \"a\": 1, \"b\": [ { \"c\": 2 } ] }"), &expected)
+	json.Unmarshal([]byte("{ \"b\": [ { \"c\": 2, \"d\": 3 } ], \"a\": 1, \"e\": 4 }"), &actual)
+
+	if JSONValuesAreEqual(expected, actual, false) {
+		t.Error("The values should differ")
+	}
+	if !JSONValuesAreEqual(expected, actual, true) {
+		t.Error("The values should be equal when ignoring extra fields")
+	}
+}
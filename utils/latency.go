@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+//GetDelay returns the delay in seconds plus the delay in milliseconds
+func GetDelay(seconds int, milliseconds int) time.Duration {
+	return time.Duration(seconds)*time.Second + time.Duration(milliseconds)*time.Millisecond
+}
+
+//GetResponseDelay returns the delay of the mock response, the delay in seconds plus the latency
+func GetResponseDelay(control definition.Control) time.Duration {
+	delay := GetDelay(control.Delay, 0)
+	if control.Latency != nil {
+		delay += time.Duration(SampleLatency(*control.Latency) * float64(time.Millisecond))
+	}
+	return delay
+}
+
+//SampleLatency returns the fixed latency or a random one from the distribution in milliseconds
+func SampleLatency(latency definition.Latency) float64 {
+	var ms float64
+	switch latency.Distribution {
+	case definition.LatencyUniform:
+		ms = float64(latency.MinMs)
+		if latency.MaxMs > latency.MinMs {
+			ms += rand.Float64() * float64(latency.MaxMs-latency.MinMs)
+		}
+		return ms
+	case definition.LatencyNormal:
+		ms = latency.MeanMs + rand.NormFloat64()*latency.StdDevMs
+	case definition.LatencyLogNormal:
+		if latency.MedianMs > 0 {
+			ms = math.Exp(math.Log(latency.MedianMs) + rand.NormFloat64()*latency.Sigma)
+		}
+	default:
+		return float64(latency.DelayMs)
+	}
+	if latency.MaxMs > 0 && ms > float64(latency.MaxMs) {
+		ms = float64(latency.MaxMs)
+	}
+	return math.Max(ms, 0)
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+func sampleLatencies(latency definition.Latency, count int) []float64 {
+	samples := make([]float64, count)
+	for i := range samples {
+		samples[i] = SampleLatency(latency)
+	}
+	sort.Float64s(samples)
+	return samples
+}
+
+func TestLatency_ResponseDelay(t *testing.T) {
+	if delay := GetResponseDelay(definition.Control{}); delay != 0 {
+		t.Error("There should be no delay by default", delay)
+	}
+	control := definition.Control{Delay: 1, Latency: &definition.Latency{DelayMs: 250}}
+	if delay := GetResponseDelay(control); delay != 1250*time.Millisecond {
+		t.Error("The delay in seconds and the fixed latency should be added", delay)
+	}
+}
+
+func TestLatency_Delay(t *testing.T) {
+	if delay := GetDelay(2, 0); delay != 2*time.Second {
+		t.Error("The delay should be in seconds", delay)
+	}
+	if delay := GetDelay(1, 500); delay != 1500*time.Millisecond {
+		t.Error("The delay in milliseconds should be added", delay)
+	}
+}
+
+func TestLatency_Uniform(t *testing.T) {
+	samples := sampleLatencies(definition.Latency{Distribution: definition.LatencyUniform, MinMs: 100, MaxMs: 200}, 1000)
+	if samples[0] < 100 || samples[len(samples)-1] > 200 || samples[500] < 130 || samples[500] > 170 {
+		t.Error("Unexpected uniform latencies", samples[0], samples[500], samples[len(samples)-1])
+	}
+}
+
+func TestLatency_Normal(t *testing.T) {
+	samples := sampleLatencies(definition.Latency{Distribution: definition.LatencyNormal, MeanMs: 100, StdDevMs: 10}, 1000)
+	if math.Abs(samples[500]-100) > 3 || samples[0] < 50 || samples[len(samples)-1] > 150 {
+		t.Error("Unexpected normal latencies", samples[0], samples[500], samples[len(samples)-1])
+	}
+
+	samples = sampleLatencies(definition.Latency{Distribution: definition.LatencyNormal, MeanMs: 10, StdDevMs: 100, MaxMs: 50}, 1000)
+	if samples[0] != 0 || samples[len(samples)-1] != 50 {
+		t.Error("The normal latencies should be between 0 and max", samples[0], samples[len(samples)-1])
+	}
+}
+
+func TestLatency_LogNormal(t *testing.T) {
+	samples := sampleLatencies(definition.Latency{Distribution: definition.LatencyLogNormal, MedianMs: 50, Sigma: 1}, 2000)
+	if math.Abs(samples[1000]-50) > 10 {
+		t.Error("Unexpected log-normal median", samples[1000])
+	}
+	// the long tail: the 99th percentile is about e^2.33 times the median
+	if p99 := samples[1980]; p99 < 300 || p99 > 1000 {
+		t.Error("Unexpected log-normal tail", p99)
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var nameCleaner = regexp.MustCompile(`[^\w]+`)
+
+//IgnoredResponseHeaders are not served from the imported responses, as they are set by the mock server
+var IgnoredResponseHeaders = []string{
+	"Connection", "Content-Encoding", "Content-Length", "Date", "Transfer-Encoding",
+}
+
+//CleanName replaces the characters which are not letters, digits or underscores with dashes e.g. /users/{id} becomes users-id
+func CleanName(value string) string {
+	return strings.Trim(nameCleaner.ReplaceAllString(value, "-"), "-")
+}
+
+//IsHeaderIgnored checks whether the header is one of the ignored ones, the names ending with * ignore all the headers starting with them
+func IsHeaderIgnored(ignored []string, header string) bool {
+	for _, name := range ignored {
+		if prefix := strings.TrimSuffix(name, "*"); prefix != name {
+			if len(header) >= len(prefix) && strings.EqualFold(header[:len(prefix)], prefix) {
+				return true
+			}
+		} else if strings.EqualFold(name, header) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package utils
+
+import "testing"
+
+func TestCleanName(t *testing.T) {
+	if name := CleanName("/users/{id}/orders_list"); name != "users-id-orders_list" {
+		t.Error("Unexpected name", name)
+	}
+}
+
+func TestIsHeaderIgnored(t *testing.T) {
+	ignored := []string{"User-Agent", "Sec-Fetch-*"}
+	if !IsHeaderIgnored(ignored, "user-agent") || !IsHeaderIgnored(ignored, "sec-fetch-mode") {
+		t.Error("The ignored headers should be case insensitive and support prefixes")
+	}
+	if IsHeaderIgnored(ignored, "Authorization") || IsHeaderIgnored(ignored, "Sec") {
+		t.Error("The other headers should not be ignored")
+	}
+}
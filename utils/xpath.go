@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+)
+
+//GetXPathValues returns the text of all the nodes in the XML document selected by the XPath expression.
+//The namespaces map the prefixes used in the expression to namespace URIs, without them the document prefixes are used.
+//The expressions evaluated to a string, number or boolean e.g. count(//item) return a single value.
+func GetXPathValues(input string, expr string, namespaces map[string]string) ([]string, error) {
+	compiled, err := compileXPath(expr, namespaces)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := xmlquery.Parse(strings.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+
+	switch result := compiled.Evaluate(xmlquery.CreateXPathNavigator(doc)).(type) {
+	case *xpath.NodeIterator:
+		values := []string{}
+		for result.MoveNext() {
+			values = append(values, strings.TrimSpace(result.Current().Value()))
+		}
+		return values, nil
+	case float64:
+		return []string{strconv.FormatFloat(result, 'f', -1, 64)}, nil
+	case bool:
+		return []string{strconv.FormatBool(result)}, nil
+	case string:
+		return []string{result}, nil
+	}
+	return []string{}, nil
+}
+
+func compileXPath(expr string, namespaces map[string]string) (*xpath.Expr, error) {
+	if len(namespaces) == 0 {
+		return xpath.Compile(expr)
+	}
+	return xpath.CompileWithNS(expr, namespaces)
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+const soapEnvelope = `<?xml version="1.0"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:m="http://example.com/orders">
+  <soap:Body>
+    <m:GetOrder id="7"><m:OrderId> 42 </m:OrderId><m:Item>a</m:Item><m:Item>b</m:Item></m:GetOrder>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestXPath_GetXPathValues(t *testing.T) {
+	namespaces := map[string]string{"o": "http://example.com/orders"}
+	cases := []struct {
+		expr       string
+		namespaces map[string]string
+		expected   []string
+	}{
+		{"//m:OrderId", nil, []string{"42"}},
+		{"//o:OrderId", namespaces, []string{"42"}},
+		{"//m:GetOrder/@id", nil, []string{"7"}},
+		{"//m:Item", nil, []string{"a", "b"}},
+		{"count(//m:Item)", nil, []string{"2"}},
+		{"//*[local-name()='Item'][2]", nil, []string{"b"}},
+		{"//m:Missing", nil, []string{}},
+	}
+	for _, c := range cases {
+		values, err := GetXPathValues(soapEnvelope, c.expr, c.namespaces)
+		if err != nil || !reflect.DeepEqual(values, c.expected) {
+			t.Error("Unexpected values", c.expr, values, err)
+		}
+	}
+}
+
+func TestXPath_Invalid(t *testing.T) {
+	if _, err := GetXPathValues(soapEnvelope, "//m:OrderId", map[string]string{"o": "http://example.com/orders"}); err == nil {
+		t.Error("The prefixes missing in the namespaces should fail")
+	}
+	if _, err := GetXPathValues(`{"id": 1}`, "//id", nil); err == nil {
+		t.Error("The documents which are not XML should fail")
+	}
+}
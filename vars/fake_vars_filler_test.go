@@ -163,3 +163,47 @@ func TestReplaceTagWithParameterNoParameterPassed(t *testing.T) {
 		t.Error("Replaced tags in body not match", mock.Response.Body)
 	}
 }
+
+func evalSeededFake(varsProcessor VarsProcessor, path string, fakeSeed string) string {
+	req := definition.Request{Method: "GET", Path: path}
+
+	mock := definition.Mock{}
+	mock.Request.Path = "/users/:id"
+	mock.Control.FakeSeed = fakeSeed
+	mock.Response.Body = "{{fake.FullName}} {{fake.UUID}} {{fake.Int(100000)}}"
+	varsProcessor.Eval(&req, &mock)
+	return mock.Response.Body
+}
+
+func TestReplaceTagsWithMockSeed(t *testing.T) {
+	varsProcessor := getProcessor("testData")
+	varsProcessor.FakeAdapter = fakedata.FakeAdapter{}
+
+	first := evalSeededFake(varsProcessor, "/users/1", "{{request.path.id}}")
+	second := evalSeededFake(varsProcessor, "/users/1", "{{request.path.id}}")
+	other := evalSeededFake(varsProcessor, "/users/2", "{{request.path.id}}")
+
+	if first != second {
+		t.Error("The same seed should generate the same data", first, second)
+	}
+	if first == other {
+		t.Error("Different seeds should generate different data", first, other)
+	}
+}
+
+func TestReplaceTagsWithGlobalSeed(t *testing.T) {
+	varsProcessor := getProcessor("testData")
+	varsProcessor.FakeAdapter = fakedata.FakeAdapter{}
+	varsProcessor.FakeSeed = "42"
+
+	first := evalSeededFake(varsProcessor, "/users/1", "")
+	second := evalSeededFake(varsProcessor, "/users/1", "")
+	other := evalSeededFake(varsProcessor, "/users/2", "")
+
+	if first != second {
+		t.Error("The same request should generate the same data", first, second)
+	}
+	if first == other {
+		t.Error("Different requests should generate different data", first, other)
+	}
+}
@@ -0,0 +1,58 @@
+package fakedata
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/icrowley/fake"
+)
+
+//SeedableDataFaker is a data faker which generates the same data for the same seed
+type SeedableDataFaker interface {
+	DataFaker
+	//Seeded returns a faker generating the data of the seed
+	Seeded(seed int64) DataFaker
+}
+
+//fakeLock guards the single random generator of the fake library. The seeded values are generated alone,
+//while the random ones are generated concurrently, but not in the middle of a seeded value.
+var fakeLock sync.RWMutex
+
+//reseed restores the random data of the fake library after a seeded value, it is used under the fake lock
+var reseed = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+//Seeded returns a faker generating the data of the seed, every value is generated with the next number of the seed sequence
+func (fa FakeAdapter) Seeded(seed int64) DataFaker {
+	return FakeAdapter{rnd: rand.New(rand.NewSource(seed))}
+}
+
+//generate returns the value of the fake library function, seeded with the adapter random numbers when there are such
+func (fa FakeAdapter) generate(value func() string) string {
+	if fa.rnd == nil {
+		fakeLock.RLock()
+		defer fakeLock.RUnlock()
+		return value()
+	}
+	seed := fa.rnd.Int63()
+	fakeLock.Lock()
+	defer fakeLock.Unlock()
+	fake.Seed(seed)
+	result := value()
+	fake.Seed(reseed.Int63())
+	return result
+}
+
+//SeedFromString returns the integer value as seed, the other values are hashed
+func SeedFromString(value string) int64 {
+	value = strings.TrimSpace(value)
+	if seed, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seed
+	}
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,79 @@
+package fakedata
+
+import (
+	"regexp"
+	"testing"
+)
+
+func generateSeeded(seed int64) []string {
+	faker := FakeAdapter{}.Seeded(seed)
+	return []string{faker.FullName(), faker.UUID(), faker.Int(1000000), faker.Float(1000), faker.EmailAddress()}
+}
+
+func TestGenerate_SameSeed(t *testing.T) {
+	first := generateSeeded(42)
+	second := generateSeeded(42)
+
+	for i := range first {
+		if first[i] != second[i] {
+			t.Error("The same seed should generate the same data", first[i], second[i])
+		}
+	}
+}
+
+func TestGenerate_DifferentSeeds(t *testing.T) {
+	first := generateSeeded(42)
+	second := generateSeeded(43)
+
+	same := 0
+	for i := range first {
+		if first[i] == second[i] {
+			same++
+		}
+	}
+	if same == len(first) {
+		t.Error("Different seeds should generate different data", first)
+	}
+}
+
+func TestGenerate_SeededUUID(t *testing.T) {
+	uuid := regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$")
+	values := generateSeeded(7)
+
+	if !uuid.MatchString(values[1]) {
+		t.Error("The seeded UUID should be a version 4 UUID", values[1])
+	}
+}
+
+func TestGenerate_ConcurrentRandomData(t *testing.T) {
+	expected := generateSeeded(42)
+	done := make(chan bool)
+	go func() {
+		faker := FakeAdapter{}
+		for i := 0; i < 1000; i++ {
+			faker.FullName()
+		}
+		done <- true
+	}()
+	for i := 0; i < 100; i++ {
+		values := generateSeeded(42)
+		for j := range values {
+			if values[j] != expected[j] {
+				t.Fatal("The random data should not change the seeded data", values[j], expected[j])
+			}
+		}
+	}
+	<-done
+}
+
+func TestSeedFromString(t *testing.T) {
+	if seed := SeedFromString(" 123 "); seed != 123 {
+		t.Error("The integer values should be used as seed", seed)
+	}
+	if SeedFromString("user-1") != SeedFromString("user-1") {
+		t.Error("The same value should give the same seed")
+	}
+	if SeedFromString("user-1") == SeedFromString("user-2") {
+		t.Error("Different values should give different seeds")
+	}
+}
@@ -12,6 +12,8 @@ type FillerFactory interface {
 	CreateFakeFiller(Fake fakedata.DataFaker) Filler
 	CreateStorageFiller(Engines *persist.PersistEngineBag) Filler
 	CreatePersistFiller(Engines *persist.PersistEngineBag) Filler
+	CreateUtilFiller() Filler
+	CreateTemplateFiller(req *definition.Request, Fake fakedata.DataFaker, Engines *persist.PersistEngineBag) Filler
 }
 
 type MockFillerFactory struct{}
@@ -31,3 +33,11 @@ func (mff MockFillerFactory) CreateStorageFiller(engines *persist.PersistEngineB
 func (mff MockFillerFactory) CreatePersistFiller(engines *persist.PersistEngineBag) Filler {
 	return PersistVarsFiller{Engines: engines, RegexHelper: utils.RegexHelper{}}
 }
+
+func (mff MockFillerFactory) CreateUtilFiller() Filler {
+	return UtilVarsFiller{}
+}
+
+func (mff MockFillerFactory) CreateTemplateFiller(req *definition.Request, fake fakedata.DataFaker, engines *persist.PersistEngineBag) Filler {
+	return TemplateFiller{Request: req, Fake: fake, Engines: engines}
+}
@@ -52,6 +52,18 @@ func (rvf RequestVarsFiller) replaceString(raw string) (string, bool) {
 	if tag == "request.body" {
 		s = rvf.Request.Body
 		found = true
+	} else if tag == "request.method" {
+		s, found = rvf.Request.Method, true
+	} else if tag == "request.host" {
+		s, found = rvf.Request.Host, true
+	} else if tag == "request.scheme" {
+		s, found = rvf.Request.Scheme, true
+	} else if tag == "request.remoteAddr" {
+		s, found = rvf.Request.RemoteAddr, true
+	} else if i := strings.Index(tag, "request.header."); i == 0 {
+		s, found = rvf.getHeaderParam(rvf.Request, tag[len("request.header."):])
+	} else if strings.HasPrefix(tag, "request.body.xpath(") && strings.HasSuffix(tag, ")") {
+		s, found = rvf.getXPathParam(rvf.Request, tag[len("request.body.xpath("):len(tag)-1])
 	} else if i := strings.Index(tag, "request.body."); i == 0 {
 		s, found = rvf.getBodyParam(rvf.Request, tag[len("request.body."):])
 	} else if i := strings.Index(tag, "request.query."); i == 0 {
@@ -60,6 +72,8 @@ func (rvf RequestVarsFiller) replaceString(raw string) (string, bool) {
 		s, found = rvf.getPathParam(tag[len("request.path."):])
 	} else if i := strings.Index(tag, "request.cookie."); i == 0 {
 		s, found = rvf.getCookieParam(rvf.Request, tag[len("request.cookie."):])
+	} else if i := strings.Index(tag, "request.tls."); i == 0 {
+		s, found = rvf.getClientCertificateParam(rvf.Request, tag[len("request.tls."):])
 	}
 	if !found {
 		return raw, false
@@ -121,6 +135,25 @@ func (rvf RequestVarsFiller) getBodyParam(req *definition.Request, name string)
 	return value, err == nil
 }
 
+//getXPathParam returns the first value selected by the XPath expression in the XML body, the mock namespaces are used for the prefixes
+func (rvf RequestVarsFiller) getXPathParam(req *definition.Request, expr string) (string, bool) {
+	values, err := utils.GetXPathValues(req.Body, expr, rvf.Mock.Request.XMLNamespaces)
+	if err != nil || len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
+//getHeaderParam returns the first value of the header, the header names are case insensitive
+func (rvf RequestVarsFiller) getHeaderParam(req *definition.Request, name string) (string, bool) {
+	for header, values := range req.Headers {
+		if strings.EqualFold(header, name) && len(values) > 0 {
+			return values[0], true
+		}
+	}
+	return "", false
+}
+
 func (rvf RequestVarsFiller) getCookieParam(req *definition.Request, name string) (string, bool) {
 
 	if len(rvf.Request.Cookies) == 0 {
@@ -133,3 +166,21 @@ func (rvf RequestVarsFiller) getCookieParam(req *definition.Request, name string
 
 	return value, true
 }
+
+func (rvf RequestVarsFiller) getClientCertificateParam(req *definition.Request, name string) (string, bool) {
+
+	if req.TLS == nil {
+		return "", false
+	}
+	switch name {
+	case "subject":
+		return req.TLS.Subject, true
+	case "issuer":
+		return req.TLS.Issuer, true
+	case "sans":
+		return strings.Join(req.TLS.SANs, ","), true
+	case "fingerprint":
+		return req.TLS.Fingerprint, true
+	}
+	return "", false
+}
@@ -102,3 +102,65 @@ func TestRequestVarsFiller_BodyPart(t *testing.T) {
 		t.Error("The result differs from the expected result", mock.Response.Body, expectedResult)
 	}
 }
+
+func TestRequestVarsFiller_ClientCertificate(t *testing.T) {
+	processor := getVarsProcessor()
+
+	req := &definition.Request{}
+	req.Path = "/users/1"
+	req.TLS = &definition.ClientCertificate{Subject: "CN=client,O=Partner", SANs: []string{"client.partner.com", "10.0.0.1"}, Fingerprint: "ab12"}
+
+	mock := &definition.Mock{}
+	mock.Request.Path = "/users/*"
+	mock.Response.Body = "{{ request.tls.subject }}|{{ request.tls.sans }}|{{ request.tls.fingerprint }}"
+
+	processor.Eval(req, mock)
+
+	expectedResult := "CN=client,O=Partner|client.partner.com,10.0.0.1|ab12"
+	if mock.Response.Body != expectedResult {
+		t.Error("The result differs from the expected result", mock.Response.Body, expectedResult)
+	}
+}
+
+func TestRequestVarsFiller_BodyXPath(t *testing.T) {
+	processor := getVarsProcessor()
+
+	req := &definition.Request{}
+	req.Body = `<ord:GetOrder xmlns:ord="http://example.com/orders"><ord:OrderId>42</ord:OrderId></ord:GetOrder>`
+
+	mock := &definition.Mock{}
+	mock.Request.XMLNamespaces = map[string]string{"o": "http://example.com/orders"}
+	mock.Response.Body = "<Id>{{request.body.xpath(//o:OrderId)}}</Id><Missing>{{ request.body.xpath(//o:Customer) }}</Missing>"
+
+	processor.Eval(req, mock)
+
+	if mock.Response.Body != "<Id>42</Id><Missing>{{ request.body.xpath(//o:Customer) }}</Missing>" {
+		t.Error("The XPath values should be replaced", mock.Response.Body)
+	}
+}
+
+func TestRequestVarsFiller_RequestDetails(t *testing.T) {
+	processor := getVarsProcessor()
+
+	req := &definition.Request{Method: "POST", Host: "api.example.com:8083", Scheme: "https", RemoteAddr: "10.0.0.7:51234"}
+	req.Headers = definition.Values{"X-Correlation-Id": []string{"c-42", "c-43"}}
+
+	mock := &definition.Mock{}
+	mock.Response.Headers = definition.Values{"X-Correlation-Id": []string{"{{request.header.x-correlation-id}}"}}
+	mock.Response.Body = "{{request.method}} {{request.scheme}}://{{request.host}} from {{request.remoteAddr}}{{request.header.X-Missing}}"
+	mock.Notify.Amqp.CorrelationID = "{{ request.header.X-Correlation-Id }}"
+	mock.Notify.Http = definition.Requests{{Path: "/audit", Body: "{{request.header.X-Correlation-Id}}"}}
+	mock.Persist.Entity = "calls/{{request.header.X-Correlation-Id}}.json"
+
+	processor.Eval(req, mock)
+
+	if mock.Response.Body != "POST https://api.example.com:8083 from 10.0.0.7:51234{{request.header.X-Missing}}" {
+		t.Error("The request details should be replaced", mock.Response.Body)
+	}
+	if mock.Response.Headers["X-Correlation-Id"][0] != "c-42" {
+		t.Error("The first header value should be replaced case insensitively", mock.Response.Headers)
+	}
+	if mock.Notify.Amqp.CorrelationID != "c-42" || mock.Notify.Http[0].Body != "c-42" || mock.Persist.Entity != "calls/c-42.json" {
+		t.Error("The header should be replaced in the notify and persist sections", mock.Notify, mock.Persist.Entity)
+	}
+}
@@ -0,0 +1,242 @@
+package vars
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+	"time"
+
+	urlmatcher "github.com/azer/url-router"
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+	"github.com/vtrifonov/http-api-mock/persist"
+	"github.com/vtrifonov/http-api-mock/vars/fakedata"
+)
+
+//TemplateFiller renders the input as Go text/template, it is used instead of the other fillers by the mocks with the go template engine.
+//The templates get the request, persist and storage context and the fake data, the template errors are logged and the input is left unchanged.
+type TemplateFiller struct {
+	Request *definition.Request
+	Fake    fakedata.DataFaker
+	Engines *persist.PersistEngineBag
+}
+
+//templateContext is the data the templates are executed with
+type templateContext struct {
+	Request templateRequest
+	Persist templatePersist
+	Storage templateStorage
+	Fake    fakedata.DataFaker
+}
+
+type templateRequest struct {
+	Method     string
+	Path       string
+	Host       string
+	Scheme     string
+	RemoteAddr string
+	Body       string
+	Query      definition.Values
+	Headers    definition.Values
+	Cookies    definition.Cookies
+	PathParams map[string]string
+	//JSON is the parsed body, nil when the body is not JSON
+	JSON interface{}
+}
+
+type templatePersist struct {
+	Entity     templateEntity
+	Collection templateCollection
+}
+
+type templateEntity struct {
+	Name   string
+	ID     string
+	engine func() persist.EntityPersister
+}
+
+type templateCollection struct {
+	Name   string
+	engine func() persist.EntityPersister
+}
+
+type templateStorage struct {
+	engine func() persist.EntityPersister
+}
+
+func (tf TemplateFiller) Fill(m *definition.Mock, input string, multipleMatch bool) string {
+	if !strings.Contains(input, "{{") {
+		return input
+	}
+	tmpl, err := template.New(m.Name).Funcs(templateFuncs).Parse(input)
+	if err != nil {
+		logging.Printf("Error parsing the template: %s\n", err.Error())
+		return input
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, tf.context(m)); err != nil {
+		logging.Printf("Error executing the template: %s\n", err.Error())
+		return input
+	}
+	return buf.String()
+}
+
+func (tf TemplateFiller) context(m *definition.Mock) templateContext {
+	// the engine is looked up only when the template uses it
+	engine := func() persist.EntityPersister { return tf.Engines.Get(m.Persist.Engine) }
+	request := templateRequest{
+		Method:     tf.Request.Method,
+		Path:       tf.Request.Path,
+		Host:       tf.Request.Host,
+		Scheme:     tf.Request.Scheme,
+		RemoteAddr: tf.Request.RemoteAddr,
+		Body:       tf.Request.Body,
+		Query:      tf.Request.QueryStringParameters,
+		Headers:    tf.Request.Headers,
+		Cookies:    tf.Request.Cookies,
+		PathParams: map[string]string{},
+	}
+	if match := urlmatcher.New(m.Request.Path).Match(tf.Request.Path); match != nil {
+		request.PathParams = match.Params
+	}
+	json.Unmarshal([]byte(tf.Request.Body), &request.JSON)
+
+	return templateContext{
+		Request: request,
+		Persist: templatePersist{
+			Entity:     templateEntity{Name: m.Persist.Entity, ID: m.Persist.EntityID, engine: engine},
+			Collection: templateCollection{Name: m.Persist.Collection, engine: engine},
+		},
+		Storage: templateStorage{engine: engine},
+		Fake:    tf.Fake,
+	}
+}
+
+//Content returns the persisted entity, empty if it doesn't exist
+func (te templateEntity) Content() string {
+	content, _ := te.engine().Read(te.Name)
+	return content
+}
+
+//JSON returns the parsed persisted entity
+func (te templateEntity) JSON() interface{} {
+	return fromJSON(te.Content())
+}
+
+//Content returns the JSON array of the collection entities
+func (tc templateCollection) Content() string {
+	content, _ := tc.engine().ReadCollection(tc.Name)
+	return content
+}
+
+//Items returns the parsed entities of the collection
+func (tc templateCollection) Items() []interface{} {
+	items, _ := fromJSON(tc.Content()).([]interface{})
+	return items
+}
+
+//Count returns the number of the entities in the collection
+func (tc templateCollection) Count() int {
+	return tc.engine().GetCollectionLength(tc.Name)
+}
+
+//Sequence increases the sequence and returns its value
+func (ts templateStorage) Sequence(name string, increase int) (int, error) {
+	return ts.engine().GetSequence(name, increase)
+}
+
+//GetValue returns the stored value, empty if it doesn't exist
+func (ts templateStorage) GetValue(key string) string {
+	value, _ := ts.engine().GetValue(key)
+	return value
+}
+
+//SetValue stores the value and returns it
+func (ts templateStorage) SetValue(key, value string) (string, error) {
+	return value, ts.engine().SetValue(key, value)
+}
+
+var templateFuncs = template.FuncMap{
+	"json":      toJSON,
+	"fromJson":  fromJSON,
+	"default":   defaultValue,
+	"first":     first,
+	"upper":     strings.ToUpper,
+	"lower":     strings.ToLower,
+	"trim":      strings.TrimSpace,
+	"replace":   strings.ReplaceAll,
+	"split":     strings.Split,
+	"join":      strings.Join,
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"add":       func(a, b int) int { return a + b },
+	"sub":       func(a, b int) int { return a - b },
+	"mul":       func(a, b int) int { return a * b },
+	"div":       func(a, b int) int { return a / b },
+	"mod":       func(a, b int) int { return a % b },
+	"seq":       seq,
+	"now":       func(layout string) string { return time.Now().Format(layout) },
+}
+
+func toJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+func fromJSON(value string) interface{} {
+	var result interface{}
+	if err := json.Unmarshal([]byte(value), &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+//defaultValue returns the value, or the default when the value is empty
+func defaultValue(def interface{}, value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return def
+	case string:
+		if v == "" {
+			return def
+		}
+	case []string:
+		if len(v) == 0 {
+			return def
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			return def
+		}
+	}
+	return value
+}
+
+//first returns the first item of the list, e.g. the first value of a query string parameter or header
+func first(list interface{}) interface{} {
+	switch items := list.(type) {
+	case []string:
+		if len(items) > 0 {
+			return items[0]
+		}
+	case []interface{}:
+		if len(items) > 0 {
+			return items[0]
+		}
+	}
+	return ""
+}
+
+//seq returns the numbers from 0 to n-1, used for ranging n times
+func seq(n int) []int {
+	if n < 0 {
+		n = 0
+	}
+	numbers := make([]int, n)
+	for i := range numbers {
+		numbers[i] = i
+	}
+	return numbers
+}
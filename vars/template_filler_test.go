@@ -0,0 +1,78 @@
+package vars
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+func getTemplateMock(body string) *definition.Mock {
+	mock := &definition.Mock{}
+	mock.Control.TemplateEngine = definition.TemplateEngineGo
+	mock.Request.Path = "/users/:userId"
+	mock.Response.Body = body
+	return mock
+}
+
+func TestTemplateFiller_Request(t *testing.T) {
+	processor := getVarsProcessor()
+
+	req := &definition.Request{Method: "POST", Path: "/users/7", Body: `{"tags": ["a", "b"], "name": "Jo"}`}
+	req.QueryStringParameters = definition.Values{"verbose": []string{"true"}}
+	req.Headers = definition.Values{"X-Trace": []string{"t1"}}
+
+	mock := getTemplateMock(`{"id": {{.Request.PathParams.userId}}, "method": "{{.Request.Method}}", "name": {{json .Request.JSON.name}}` +
+		`{{if eq (first .Request.Query.verbose) "true"}}, "tags": [{{range $i, $tag := .Request.JSON.tags}}{{if $i}}, {{end}}"{{upper $tag}}"{{end}}]{{end}}` +
+		`, "trace": "{{first (index .Request.Headers "X-Trace")}}", "page": {{default 1 (first .Request.Query.page)}}, "by": "{{.Fake.FirstName}}"}`)
+	processor.Eval(req, mock)
+
+	expected := `{"id": 7, "method": "POST", "name": "Jo", "tags": ["A", "B"], "trace": "t1", "page": 1, "by": "AleixMG"}`
+	if mock.Response.Body != expected {
+		t.Error("Unexpected body", mock.Response.Body)
+	}
+}
+
+func TestTemplateFiller_InvalidTemplate(t *testing.T) {
+	processor := getVarsProcessor()
+
+	mock := getTemplateMock("{{ request.body }}")
+	processor.Eval(&definition.Request{}, mock)
+	if mock.Response.Body != "{{ request.body }}" {
+		t.Error("The invalid templates should not be changed", mock.Response.Body)
+	}
+
+	mock = getTemplateMock("{{ .Request.Missing }}")
+	processor.Eval(&definition.Request{}, mock)
+	if mock.Response.Body != "{{ .Request.Missing }}" {
+		t.Error("The templates failing to execute should not be changed", mock.Response.Body)
+	}
+}
+
+func TestTemplateFiller_PersistAndStorage(t *testing.T) {
+	persistPath, _ := filepath.Abs("./test_persist")
+	defer os.RemoveAll(persistPath)
+	os.RemoveAll(persistPath)
+	processor := getFileProcessor(persistPath)
+
+	for _, name := range []string{"Ann", "Bob"} {
+		req := &definition.Request{Body: `{"name": "` + name + `"}`}
+		mock := getTemplateMock(`{"id": {{.Persist.Entity.ID}}, "saved": {{.Persist.Entity.Content}}}`)
+		mock.Persist.EntityID = "{{.Storage.Sequence \"users\" 1}}"
+		mock.Persist.Entity = "users/user-{{.Persist.Entity.ID}}.json"
+		mock.Persist.Actions = definition.Actions{"write": "{{.Request.Body}}"}
+		processor.Eval(req, mock)
+
+		if mock.Persist.Entity == "users/user-.json" || mock.Response.Body == "" {
+			t.Error("The persist fields should be rendered before the actions", mock.Persist.Entity, mock.Response.Body)
+		}
+	}
+
+	mock := getTemplateMock(`{{.Persist.Collection.Count}}:{{range .Persist.Collection.Items}}{{.name}};{{end}}`)
+	mock.Persist.Collection = "users"
+	processor.Eval(&definition.Request{}, mock)
+	if mock.Response.Body != "2:Ann;Bob;" {
+		t.Error("The collection should be rendered", mock.Response.Body)
+	}
+}
@@ -0,0 +1,260 @@
+package vars
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+	"github.com/vtrifonov/http-api-mock/logging"
+)
+
+var (
+	errUnknownUtilFunction = errors.New("Unknown util function")
+	errInvalidUtilArgs     = errors.New("Invalid util function arguments")
+)
+
+//timeLayouts are the named layouts which can be used instead of the Go layouts
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+}
+
+//UtilVarsFiller fills the util helper tags like {{util.Now("2006-01-02")}}, the arguments are separated by commas and can be quoted.
+//The helpers with a single argument take the whole arguments string.
+//The tags with unknown functions or invalid arguments are left unchanged.
+type UtilVarsFiller struct {
+	//Now returns the current time, it can be replaced in the tests
+	Now func() time.Time
+}
+
+func (uvf UtilVarsFiller) Fill(m *definition.Mock, input string, multipleMatch bool) string {
+	// the arguments can't contain tags, so the nested helpers are filled first in the consecutive passes
+	r := regexp.MustCompile(`\{\{\s*util\.(\w+)(?:\(((?:[^{]|\{[^{])*?)\))?\s*\}\}`)
+	for tries := 0; tries <= 3; tries++ {
+		filled := r.ReplaceAllStringFunc(input, func(raw string) string {
+			parts := r.FindStringSubmatch(raw)
+			value, err := uvf.call(parts[1], parts[2])
+			if err != nil {
+				logging.Printf("Error filling %s: %s\n", raw, err.Error())
+				return raw
+			}
+			return value
+		})
+		if filled == input {
+			break
+		}
+		input = filled
+	}
+	return input
+}
+
+func (uvf UtilVarsFiller) now() time.Time {
+	if uvf.Now != nil {
+		return uvf.Now()
+	}
+	return time.Now()
+}
+
+func (uvf UtilVarsFiller) call(name string, rawArgs string) (string, error) {
+	args := parseUtilArgs(rawArgs)
+	switch name {
+	case "Now":
+		return uvf.formatTime(uvf.now(), args)
+	case "NowAdd":
+		if len(args) == 0 {
+			return "", errInvalidUtilArgs
+		}
+		offset, err := parseOffset(args[0])
+		if err != nil {
+			return "", err
+		}
+		return uvf.formatTime(uvf.now().Add(offset), args[1:])
+	case "Epoch", "EpochMillis":
+		t := uvf.now()
+		if len(args) > 0 {
+			offset, err := parseOffset(args[0])
+			if err != nil {
+				return "", err
+			}
+			t = t.Add(offset)
+		}
+		if name == "EpochMillis" {
+			return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10), nil
+		}
+		return strconv.FormatInt(t.Unix(), 10), nil
+	case "Add", "Sub", "Mul", "Div", "Mod":
+		return calculate(name, args)
+	case "Base64Encode":
+		return withOneArg(rawArgs, func(s string) (string, error) {
+			return base64.StdEncoding.EncodeToString([]byte(s)), nil
+		})
+	case "Base64Decode":
+		return withOneArg(rawArgs, func(s string) (string, error) {
+			data, err := base64.StdEncoding.DecodeString(s)
+			return string(data), err
+		})
+	case "URLEncode":
+		return withOneArg(rawArgs, func(s string) (string, error) {
+			return url.QueryEscape(s), nil
+		})
+	case "URLDecode":
+		return withOneArg(rawArgs, url.QueryUnescape)
+	case "SHA256":
+		return withOneArg(rawArgs, func(s string) (string, error) {
+			sum := sha256.Sum256([]byte(s))
+			return hex.EncodeToString(sum[:]), nil
+		})
+	case "HMACSHA256":
+		if len(args) != 2 {
+			return "", errInvalidUtilArgs
+		}
+		mac := hmac.New(sha256.New, []byte(args[0]))
+		mac.Write([]byte(args[1]))
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	case "JSONEscape":
+		return withOneArg(rawArgs, func(s string) (string, error) {
+			data, err := json.Marshal(s)
+			return string(data[1 : len(data)-1]), err
+		})
+	}
+	return "", errUnknownUtilFunction
+}
+
+//formatTime formats the time with the optional layout (RFC3339 by default) in the optional time zone (UTC by default)
+func (uvf UtilVarsFiller) formatTime(t time.Time, args []string) (string, error) {
+	layout := time.RFC3339
+	if len(args) > 0 && args[0] != "" {
+		layout = args[0]
+		if named, ok := timeLayouts[layout]; ok {
+			layout = named
+		}
+	}
+	location := time.UTC
+	if len(args) > 1 && args[1] != "" {
+		var err error
+		if location, err = time.LoadLocation(args[1]); err != nil {
+			return "", err
+		}
+	}
+	return t.In(location).Format(layout), nil
+}
+
+//parseOffset parses a duration like "2h", "-30m" or "+1d12h", the days are 24 hours
+func parseOffset(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	sign := time.Duration(1)
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+		value = value[1:]
+	} else {
+		value = strings.TrimPrefix(value, "+")
+	}
+	var days time.Duration
+	if i := strings.Index(value, "d"); i >= 0 {
+		count, err := strconv.Atoi(value[:i])
+		if err != nil {
+			return 0, errInvalidUtilArgs
+		}
+		days = time.Duration(count) * 24 * time.Hour
+		value = value[i+1:]
+	}
+	var rest time.Duration
+	if value != "" {
+		var err error
+		if rest, err = time.ParseDuration(value); err != nil {
+			return 0, err
+		}
+	}
+	return sign * (days + rest), nil
+}
+
+func calculate(operation string, args []string) (string, error) {
+	if len(args) != 2 {
+		return "", errInvalidUtilArgs
+	}
+	a, errA := strconv.ParseFloat(args[0], 64)
+	b, errB := strconv.ParseFloat(args[1], 64)
+	if errA != nil || errB != nil {
+		return "", errInvalidUtilArgs
+	}
+	var result float64
+	switch operation {
+	case "Add":
+		result = a + b
+	case "Sub":
+		result = a - b
+	case "Mul":
+		result = a * b
+	case "Div", "Mod":
+		if b == 0 {
+			return "", errInvalidUtilArgs
+		}
+		if operation == "Div" {
+			result = a / b
+		} else {
+			result = float64(int64(a) % int64(b))
+		}
+	}
+	return strconv.FormatFloat(result, 'f', -1, 64), nil
+}
+
+//withOneArg calls the function with the whole arguments string, so the commas in the values filled from the other vars don't matter
+func withOneArg(rawArgs string, f func(string) (string, error)) (string, error) {
+	arg := strings.TrimSpace(rawArgs)
+	if len(arg) >= 2 && (arg[0] == '"' || arg[0] == '\'') && arg[len(arg)-1] == arg[0] {
+		arg = arg[1 : len(arg)-1]
+	}
+	return f(arg)
+}
+
+//parseUtilArgs splits the arguments by the commas outside of the quotes, the quotes around the arguments are removed
+func parseUtilArgs(input string) []string {
+	if strings.TrimSpace(input) == "" {
+		return nil
+	}
+	args := []string{}
+	var current strings.Builder
+	var quote rune
+	quoted := false
+	for _, c := range input {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && !quoted && (c == '"' || c == '\'') && strings.TrimSpace(current.String()) == "":
+			quote = c
+			quoted = true
+			current.Reset()
+		case quote == 0 && c == ',':
+			args = append(args, utilArg(current.String(), quoted))
+			current.Reset()
+			quoted = false
+		case quote == 0 && quoted:
+			// the characters between the closing quote and the comma are ignored
+		default:
+			current.WriteRune(c)
+		}
+	}
+	return append(args, utilArg(current.String(), quoted))
+}
+
+func utilArg(value string, quoted bool) string {
+	if quoted {
+		return value
+	}
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,58 @@
+package vars
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vtrifonov/http-api-mock/definition"
+)
+
+func TestUtilVarsFiller_Fill(t *testing.T) {
+	filler := UtilVarsFiller{Now: func() time.Time { return time.Date(2024, 2, 28, 22, 30, 15, 0, time.UTC) }}
+	cases := map[string]string{
+		`{{util.Now}}`:                              "2024-02-28T22:30:15Z",
+		`{{ util.Now("2006-01-02 15:04") }}`:        "2024-02-28 22:30",
+		`{{util.Now(RFC1123, "Europe/Sofia")}}`:     "Thu, 29 Feb 2024 00:30:15 EET",
+		`{{util.NowAdd("+1d2h", "2006-01-02T15")}}`: "2024-03-01T00",
+		`{{util.NowAdd(-90m, Kitchen)}}`:            "9:00PM",
+		`{{util.Epoch}}`:                            "1709159415",
+		`{{util.Epoch(1h)}}`:                        "1709163015",
+		`{{util.EpochMillis}}`:                      "1709159415000",
+		`{{util.Add(2, 3.5)}}`:                      "5.5",
+		`{{util.Sub(10, 4)}}`:                       "6",
+		`{{util.Mul(3, 4)}}`:                        "12",
+		`{{util.Div(7, 2)}}`:                        "3.5",
+		`{{util.Mod(7, 2)}}`:                        "1",
+		`{{util.Base64Encode("user:pass, 1")}}`:     "dXNlcjpwYXNzLCAx",
+		`{{util.Base64Decode(dXNlcjpwYXNzLCAx)}}`:   "user:pass, 1",
+		`{{util.URLEncode('a b&c')}}`:               "a+b%26c",
+		`{{util.URLDecode(a+b%26c)}}`:               "a b&c",
+		`{{util.SHA256(abc)}}`:                      "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		`{{util.HMACSHA256("key", "The quick brown fox jumps over the lazy dog")}}`: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		`{"text": "{{util.JSONEscape('say "hi"')}}"}`:                               `{"text": "say \"hi\""}`,
+		`{{util.Base64Encode(jo:{{util.Epoch}})}}`:                                  "am86MTcwOTE1OTQxNQ==",
+		`{{util.JSONEscape(say "hi", ok)}}`:                                         `say \"hi\", ok`,
+		`{{util.Div(1, 0)}}`:                                                        `{{util.Div(1, 0)}}`,
+		`{{util.Add(a, 1)}}`:                                                        `{{util.Add(a, 1)}}`,
+		`{{util.Now(RFC3339, "Mars/Olympus")}}`:                                     `{{util.Now(RFC3339, "Mars/Olympus")}}`,
+		`{{util.Unknown}}`:                                                          `{{util.Unknown}}`,
+	}
+	for input, expected := range cases {
+		if actual := filler.Fill(&definition.Mock{}, input, false); actual != expected {
+			t.Error("Unexpected value", input, actual)
+		}
+	}
+}
+
+func TestUtilVarsFiller_RequestArguments(t *testing.T) {
+	processor := getVarsProcessor()
+
+	req := &definition.Request{Body: `{"price": 40, "quantity": 3}`}
+	mock := &definition.Mock{}
+	mock.Response.Body = `{"total": {{util.Mul({{request.body.price}}, {{request.body.quantity}})}}}`
+	processor.Eval(req, mock)
+
+	if mock.Response.Body != `{"total": 120}` {
+		t.Error("The request values should be used as arguments", mock.Response.Body)
+	}
+}
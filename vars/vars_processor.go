@@ -10,18 +10,28 @@ type VarsProcessor struct {
 	FillerFactory  FillerFactory
 	FakeAdapter    fakedata.DataFaker
 	PersistEngines *persist.PersistEngineBag
+	//FakeSeed makes the fake data of the mocks without their own seed deterministic, it is combined with the request method and path
+	FakeSeed string
 }
 
 func (fp VarsProcessor) Eval(req *definition.Request, m *definition.Mock) {
+	if m.Control.TemplateEngine == definition.TemplateEngineGo {
+		fp.evalTemplates(req, m)
+		return
+	}
+
 	requestFiller := fp.FillerFactory.CreateRequestFiller(req, m)
-	fakeFiller := fp.FillerFactory.CreateFakeFiller(fp.FakeAdapter)
+	fakeFiller := fp.FillerFactory.CreateFakeFiller(fp.faker(req, m))
 	storageFiller := fp.FillerFactory.CreateStorageFiller(fp.PersistEngines)
+	utilFiller := fp.FillerFactory.CreateUtilFiller()
 	persistFiller := fp.FillerFactory.CreatePersistFiller(fp.PersistEngines)
 	entityActions := persist.EntityActions{fp.PersistEngines}
 
 	fp.walkAndFill(requestFiller, m, true)
 	fp.walkAndFill(fakeFiller, m, true)
 	fp.walkAndFill(storageFiller, m, true)
+	// the util helpers are filled after the other vars, so they can be used as their arguments
+	fp.walkAndFill(utilFiller, m, true)
 
 	// we need to make sure the persisted vars are filled before executing the actions - as we need to make sure the persist vars are replaced in the persist actions
 	fp.walkAndFillPersisted(persistFiller, m)
@@ -32,6 +42,57 @@ func (fp VarsProcessor) Eval(req *definition.Request, m *definition.Mock) {
 
 }
 
+//evalTemplates renders the mock fields as Go templates, the persist fields are rendered before applying the persist actions like with the vars
+func (fp VarsProcessor) evalTemplates(req *definition.Request, m *definition.Mock) {
+	templateFiller := fp.FillerFactory.CreateTemplateFiller(req, fp.faker(req, m), fp.PersistEngines)
+	entityActions := persist.EntityActions{Engines: fp.PersistEngines}
+
+	fp.walkAndFillPersisted(templateFiller, m)
+	entityActions.ApplyActions(m)
+	fp.walkAndFill(templateFiller, m, false)
+}
+
+//EvalText returns the text with the request, fake, storage and util vars filled, it is used for the messages which are not part of the response
+func (fp VarsProcessor) EvalText(req *definition.Request, m *definition.Mock, text string) string {
+	if m.Control.TemplateEngine == definition.TemplateEngineGo {
+		return fp.FillerFactory.CreateTemplateFiller(req, fp.faker(req, m), fp.PersistEngines).Fill(m, text, false)
+	}
+	fillers := []Filler{
+		fp.FillerFactory.CreateRequestFiller(req, m),
+		fp.FillerFactory.CreateFakeFiller(fp.faker(req, m)),
+		fp.FillerFactory.CreateStorageFiller(fp.PersistEngines),
+		fp.FillerFactory.CreateUtilFiller(),
+	}
+	for _, f := range fillers {
+		text = f.Fill(m, text, false)
+	}
+	return text
+}
+
+//faker returns the faker seeded for the mock, the fakers which can't be seeded are used as they are
+func (fp VarsProcessor) faker(req *definition.Request, m *definition.Mock) fakedata.DataFaker {
+	seedable, ok := fp.FakeAdapter.(fakedata.SeedableDataFaker)
+	if !ok {
+		return fp.FakeAdapter
+	}
+	if seed, seeded := fp.fakeSeed(req, m); seeded {
+		return seedable.Seeded(seed)
+	}
+	return fp.FakeAdapter
+}
+
+//fakeSeed returns the seed of the mock filled with the request vars, or the global seed combined with the request method and path
+func (fp VarsProcessor) fakeSeed(req *definition.Request, m *definition.Mock) (int64, bool) {
+	if m.Control.FakeSeed != "" {
+		seed := fp.FillerFactory.CreateRequestFiller(req, m).Fill(m, m.Control.FakeSeed, false)
+		return fakedata.SeedFromString(seed), true
+	}
+	if fp.FakeSeed != "" {
+		return fakedata.SeedFromString(fp.FakeSeed + " " + req.Method + " " + req.Path), true
+	}
+	return 0, false
+}
+
 func (fp VarsProcessor) walkAndFill(f Filler, m *definition.Mock, fillPersisted bool) {
 	res := &m.Response
 	for header, values := range res.Headers {
@@ -46,6 +107,31 @@ func (fp VarsProcessor) walkAndFill(f Filler, m *definition.Mock, fillPersisted
 
 	res.Body = f.Fill(m, res.Body, false)
 
+	for i, chunk := range res.Chunks {
+		res.Chunks[i].Body = f.Fill(m, chunk.Body, false)
+	}
+	for i, event := range res.Events {
+		res.Events[i].ID = f.Fill(m, event.ID, false)
+		res.Events[i].Event = f.Fill(m, event.Event, false)
+		res.Events[i].Data = f.Fill(m, event.Data, false)
+	}
+
+	if res.GRPC != nil {
+		res.GRPC.Message = f.Fill(m, res.GRPC.Message, false)
+		for trailer, values := range res.GRPC.Trailers {
+			for i, value := range values {
+				res.GRPC.Trailers[trailer][i] = f.Fill(m, value, false)
+			}
+		}
+	}
+
+	if res.SOAPFault != nil {
+		res.SOAPFault.Code = f.Fill(m, res.SOAPFault.Code, false)
+		res.SOAPFault.Reason = f.Fill(m, res.SOAPFault.Reason, false)
+		res.SOAPFault.Actor = f.Fill(m, res.SOAPFault.Actor, false)
+		res.SOAPFault.Detail = f.Fill(m, res.SOAPFault.Detail, false)
+	}
+
 	fp.walkAndFillNotify(f, m)
 
 	if fillPersisted {
@@ -56,6 +142,10 @@ func (fp VarsProcessor) walkAndFill(f Filler, m *definition.Mock, fillPersisted
 func (fp VarsProcessor) walkAndFillNotify(f Filler, m *definition.Mock) {
 	amqp := &m.Notify.Amqp
 	amqp.Body = f.Fill(m, amqp.Body, false)
+	amqp.RoutingKey = f.Fill(m, amqp.RoutingKey, false)
+	amqp.CorrelationID = f.Fill(m, amqp.CorrelationID, false)
+	amqp.MessageID = f.Fill(m, amqp.MessageID, false)
+	amqp.ReplyTo = f.Fill(m, amqp.ReplyTo, false)
 
 	http := m.Notify.Http
 